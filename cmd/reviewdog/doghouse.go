@@ -65,7 +65,7 @@ var projectRunAndParse = project.RunAndParse
 func checkResultSet(ctx context.Context, r io.Reader, opt *option, isProject bool) (*reviewdog.ResultMap, error) {
 	resultSet := new(reviewdog.ResultMap)
 	if isProject {
-		conf, err := projectConfig(opt.conf)
+		conf, err := projectConfig(opt)
 		if err != nil {
 			return nil, err
 		}
@@ -16,6 +16,7 @@ import (
 	"strconv"
 	"strings"
 	"text/tabwriter"
+	"time"
 
 	"code.gitea.io/sdk/gitea"
 	"golang.org/x/build/gerrit"
@@ -32,12 +33,16 @@ import (
 	"github.com/reviewdog/reviewdog/filter"
 	"github.com/reviewdog/reviewdog/parser"
 	"github.com/reviewdog/reviewdog/project"
+	"github.com/reviewdog/reviewdog/proto/rdf"
+	"github.com/reviewdog/reviewdog/service/azure/azureutils"
 	bbservice "github.com/reviewdog/reviewdog/service/bitbucket"
 	gerritservice "github.com/reviewdog/reviewdog/service/gerrit"
 	giteaservice "github.com/reviewdog/reviewdog/service/gitea"
 	githubservice "github.com/reviewdog/reviewdog/service/github"
 	"github.com/reviewdog/reviewdog/service/github/githubutils"
 	gitlabservice "github.com/reviewdog/reviewdog/service/gitlab"
+	"github.com/reviewdog/reviewdog/service/teamcity/teamcityutils"
+	"github.com/reviewdog/reviewdog/service/webhook"
 )
 
 const usageMessage = "" +
@@ -47,37 +52,64 @@ const usageMessage = "" +
 	GitHub if you use reviewdog in CI service.`
 
 type option struct {
-	version          bool
-	diffCmd          string
-	diffStrip        int
-	efms             strslice
-	f                string // format name
-	fDiffStrip       int
-	list             bool   // list supported errorformat name
-	name             string // tool name which is used in comment
-	conf             string
-	runners          string
-	reporter         string
-	level            string
-	guessPullRequest bool
-	tee              bool
-	filterMode       filter.Mode
-	failOnError      bool
-	failLevel        reviewdog.FailLevel
-	logLevel         string
+	version             bool
+	diffCmd             string
+	diffStrip           int
+	efms                strslice
+	f                   formatnames // format name
+	fDiffStrip          int
+	fSarifSkipUnchanged bool
+	lenient             parser.LenientMode
+	columnUnit          rdf.ColumnUnit
+	list                bool   // list supported errorformat name
+	name                string // tool name which is used in comment
+	conf                string
+	runners             string
+	reporter            string
+	template            string
+	webhookURL          string
+	webhookHeaders      strslice
+	webhookBatchSize    int
+	webhookMaxRetries   int
+	githubResolveThread bool
+	githubReviewEvent   githubservice.ReviewEvent
+	level               string
+	guessPullRequest    bool
+	tee                 bool
+	filterMode          filter.Mode
+	failOnError         bool
+	failLevel           reviewdog.FailLevel
+	logLevel            string
 }
 
 const (
-	diffCmdDoc    = `diff command (e.g. "git diff") for local reporters. Do not use --relative flag for git command.`
-	diffStripDoc  = "strip NUM leading components from diff file names (equivalent to 'patch -p') (default is 1 for git diff)"
-	efmsDoc       = `list of supported machine-readable format and errorformat (https://github.com/reviewdog/errorformat)`
-	fDoc          = `format name (run -list to see supported format name) for input. It's also used as tool name in review comment if -name is empty`
-	fDiffStripDoc = `option for -f=diff: strip NUM leading components from diff file names (equivalent to 'patch -p') (default is 1 for git diff)`
-	listDoc       = `list supported pre-defined format names which can be used as -f arg`
-	nameDoc       = `tool name in review comment. -f is used as tool name if -name is empty`
+	diffCmdDoc             = `diff command (e.g. "git diff") for local reporters. Do not use --relative flag for git command.`
+	diffStripDoc           = "strip NUM leading components from diff file names (equivalent to 'patch -p') (default is 1 for git diff)"
+	efmsDoc                = `list of supported machine-readable format and errorformat (https://github.com/reviewdog/errorformat)`
+	fDoc                   = `format name (run -list to see supported format name) for input. It's also used as tool name in review comment if -name is empty. Specify it multiple times or separate names by comma (e.g. -f=golint,tsc) for mixed output of multiple tools`
+	fDiffStripDoc          = `option for -f=diff: strip NUM leading components from diff file names (equivalent to 'patch -p') (default is 1 for git diff)`
+	fSarifSkipUnchangedDoc = `option for -f=sarif: skip results whose baselineState is "unchanged"`
+	listDoc                = `list supported pre-defined format names which can be used as -f arg`
+	nameDoc                = `tool name in review comment. -f is used as tool name if -name is empty`
 
 	confDoc             = `config file path`
 	runnersDoc          = `comma separated runners name to run in config file. default: run all runners`
+	templateDoc         = `Go text/template file for -reporter=template`
+	webhookURLDoc       = `URL to post results for -reporter=webhook. REVIEWDOG_WEBHOOK_URL environment variable is used if it's empty`
+	webhookHeaderDoc    = `additional HTTP header (e.g. "Authorization: Bearer xxx") for -reporter=webhook. Specify it multiple times for multiple headers`
+	webhookBatchSizeDoc = `max number of results in a request for -reporter=webhook. 0 means all results in a request`
+	webhookRetriesDoc   = `max number of retries on network errors and 429 or 5xx responses for -reporter=webhook`
+	githubResolveDoc    = `resolve review threads of outdated comments instead of deleting the comments for -reporter=github-pr-review. The threads are unresolved when the results are reported again`
+	githubReviewDoc     = `review event for -reporter=github-pr-review. [comment(default),request-changes,approve]
+		"comment"
+			Always submit COMMENT reviews.
+		"request-changes"
+			Submit REQUEST_CHANGES reviews if results meet -fail-level, and dismiss
+			them once results no longer meet -fail-level.
+		"approve"
+			Same as "request-changes", but approve the pull request if results of
+			the tool don't meet -fail-level and no other tools request changes.
+			It's decided per tool (runner) when its results are reported.`
 	levelDoc            = `default report level for supported reporters ("info","warning","error").`
 	guessPullRequestDoc = `guess Pull Request ID by branch name and commit SHA`
 	teeDoc              = `enable "tee"-like mode which outputs tools's output as is while reporting results to -reporter. Useful for debugging as well.`
@@ -91,11 +123,35 @@ const (
 			Filter by added/modified file.
 		"nofilter"
 			Do not filter any results.
+`
+	lenientDoc = `how to handle invalid records (e.g. a malformed line of rdjsonl) in input of rdjson, rdjsonl and sarif. [off, warning, diagnostic].
+		"off" (default)
+			Fail if input has any invalid records.
+		"warning"
+			Skip invalid records and log a warning summary.
+		"diagnostic"
+			Skip invalid records and report them as diagnostics whose source is reviewdog.
+		It's used as default for runners in config file.
+`
+	columnUnitDoc = `unit of columns in input. Columns are converted into UTF-8 bytes by reading source files. [byte, utf16, codepoint].
+		"byte" (default)
+			Columns are counted in UTF-8 bytes.
+		"utf16"
+			Columns are counted in UTF-16 code units (e.g. JavaScript tools).
+		"codepoint"
+			Columns are counted in Unicode code points.
+		It's ignored by formats which convert columns by themselves (sarif, lsp, eslint-json, ruff-json and shellcheck-json1).
+		It's used as default for runners in config file.
 `
 	reporterDoc = `reporter of reviewdog results.
 	"local" (default)
 		Report results to stdout.
 
+	"local-pretty"
+		Report results to stdout with source code frames, related locations,
+		rule links and suggestion diffs. Output is colored if stdout is a
+		terminal and NO_COLOR environment variable is not set.
+
 	"rdjson"
 		Report results to stdout in rdjson format.
 
@@ -103,7 +159,46 @@ const (
 		Report results to stdout in rdjsonl format.
 
 	"sarif"
-		Report results to stdout in SARIF format.
+		Report results to stdout in SARIF format. Each tool (runner) is a run.
+
+	"gitlab-code-quality"
+		Report results to stdout in GitLab Code Quality (Code Climate JSON)
+		format. Save it as an artifact of "codequality" report, so that GitLab
+		shows results in merge request widget and diff.
+
+	"junit"
+		Report results to stdout in JUnit XML format. Each tool is a testsuite and
+		each result is a failing testcase.
+
+	"checkstyle"
+		Report results to stdout in checkstyle XML format.
+
+	"html"
+		Report results to stdout as a self-contained static HTML file with a
+		summary, code excerpts, suggestion previews and filtering.
+
+	"template"
+		Report results to stdout with Go text/template file specified by
+		-template. The template is rendered once with all results (.Comments),
+		or for each result if it only defines "comment" template.
+		Helper functions: severity, toolName, relpath, snippet, fingerprint and
+		markdown.
+
+	"azure-pipelines-annotations"
+		Report results to Azure Pipelines as issues (errors and warnings) via
+		task.logissue logging commands. It works with -diff flag like local
+		reporters.
+
+	"teamcity"
+		Report results to TeamCity Inspections tab via inspectionType and
+		inspection service messages. It works with -diff flag like local
+		reporters.
+
+	"webhook"
+		Post results to -webhook-url as a JSON document which has build info,
+		results of each tool and summary counts. Set REVIEWDOG_WEBHOOK_SECRET
+		to sign requests with HMAC-SHA256 (X-Reviewdog-Signature-256 header).
+		It works with -diff flag like local reporters.
 
 	"github-check"
 		Report results to GitHub Check. It works both for Pull Requests and commits.
@@ -222,13 +317,23 @@ func init() {
 	flag.StringVar(&opt.diffCmd, "diff", "", diffCmdDoc)
 	flag.IntVar(&opt.diffStrip, "strip", 1, diffStripDoc)
 	flag.Var(&opt.efms, "efm", efmsDoc)
-	flag.StringVar(&opt.f, "f", "", fDoc)
+	flag.Var(&opt.f, "f", fDoc)
 	flag.IntVar(&opt.fDiffStrip, "f.diff.strip", 1, fDiffStripDoc)
+	flag.BoolVar(&opt.fSarifSkipUnchanged, "f.sarif.skip-unchanged", false, fSarifSkipUnchangedDoc)
+	flag.Var(&opt.lenient, "lenient", lenientDoc)
+	flag.Var(&opt.columnUnit, "column-unit", columnUnitDoc)
 	flag.BoolVar(&opt.list, "list", false, listDoc)
 	flag.StringVar(&opt.name, "name", "", nameDoc)
 	flag.StringVar(&opt.conf, "conf", "", confDoc)
 	flag.StringVar(&opt.runners, "runners", "", runnersDoc)
 	flag.StringVar(&opt.reporter, "reporter", "local", reporterDoc)
+	flag.StringVar(&opt.template, "template", "", templateDoc)
+	flag.StringVar(&opt.webhookURL, "webhook-url", "", webhookURLDoc)
+	flag.Var(&opt.webhookHeaders, "webhook-header", webhookHeaderDoc)
+	flag.IntVar(&opt.webhookBatchSize, "webhook-batch-size", 0, webhookBatchSizeDoc)
+	flag.IntVar(&opt.webhookMaxRetries, "webhook-max-retries", 3, webhookRetriesDoc)
+	flag.BoolVar(&opt.githubResolveThread, "github-resolve-outdated", false, githubResolveDoc)
+	flag.Var(&opt.githubReviewEvent, "github-review-event", githubReviewDoc)
 	flag.StringVar(&opt.level, "level", "", levelDoc)
 	flag.BoolVar(&opt.guessPullRequest, "guess", false, guessPullRequestDoc)
 	flag.BoolVar(&opt.tee, "tee", false, teeDoc)
@@ -290,7 +395,7 @@ func run(r io.Reader, w io.Writer, opt *option) error {
 	}
 
 	if opt.list {
-		return runList(w)
+		return runList(w, opt)
 	}
 
 	if opt.tee {
@@ -306,7 +411,7 @@ func run(r io.Reader, w io.Writer, opt *option) error {
 
 	if isProject {
 		var err error
-		projectConf, err = projectConfig(opt.conf)
+		projectConf, err = projectConfig(opt)
 		if err != nil {
 			return err
 		}
@@ -431,6 +536,38 @@ func run(r io.Reader, w io.Writer, opt *option) error {
 			return err
 		}
 		ds = d
+	case "azure-pipelines-annotations":
+		d, err := localDiffService(opt)
+		if err != nil {
+			return err
+		}
+		ds = d
+		cs = azureutils.NewAzurePipelinesLogWriter(w, opt.level)
+	case "teamcity":
+		d, err := localDiffService(opt)
+		if err != nil {
+			return err
+		}
+		ds = d
+		cs = teamcityutils.NewTeamCityInspectionWriter(w, opt.level)
+	case "webhook":
+		wh, err := webhookService(ctx, opt)
+		if err != nil {
+			return err
+		}
+		d, err := localDiffService(opt)
+		if err != nil {
+			return err
+		}
+		ds = d
+		cs = reviewdog.NewReportCommentService(wh)
+	case "local-pretty":
+		d, err := localDiffService(opt)
+		if err != nil {
+			return err
+		}
+		ds = d
+		cs = reviewdog.NewPrettyCommentWriter(w, colorEnabled(w))
 	case "rdjson":
 		d, err := localDiffService(opt)
 		if err != nil {
@@ -452,6 +589,52 @@ func run(r io.Reader, w io.Writer, opt *option) error {
 		}
 		ds = d
 		cs = reviewdog.NewSARIFCommentWriter(w, toolName(opt))
+	case "gitlab-code-quality":
+		d, err := localDiffService(opt)
+		if err != nil {
+			return err
+		}
+		ds = d
+		cs = reviewdog.NewCodeQualityCommentWriter(w)
+	case "junit":
+		d, err := localDiffService(opt)
+		if err != nil {
+			return err
+		}
+		ds = d
+		cs = reviewdog.NewJUnitCommentWriter(w, toolName(opt))
+	case "checkstyle":
+		d, err := localDiffService(opt)
+		if err != nil {
+			return err
+		}
+		ds = d
+		cs = reviewdog.NewCheckStyleCommentWriter(w)
+	case "html":
+		d, err := localDiffService(opt)
+		if err != nil {
+			return err
+		}
+		ds = d
+		cs = reviewdog.NewHTMLCommentWriter(w)
+	case "template":
+		if opt.template == "" {
+			return errors.New("-template is required for template reporter")
+		}
+		b, err := os.ReadFile(opt.template)
+		if err != nil {
+			return err
+		}
+		tcs, err := reviewdog.NewTemplateCommentWriter(w, string(b))
+		if err != nil {
+			return err
+		}
+		d, err := localDiffService(opt)
+		if err != nil {
+			return err
+		}
+		ds = d
+		cs = tcs
 	}
 
 	if isProject {
@@ -467,13 +650,27 @@ func run(r io.Reader, w io.Writer, opt *option) error {
 	return app.Run(ctx, r)
 }
 
-func runList(w io.Writer) error {
+func runList(w io.Writer, opt *option) error {
+	conf, err := parserConfig(opt)
+	if err != nil {
+		return err
+	}
 	tabw := tabwriter.NewWriter(w, 0, 8, 0, '\t', 0)
+	for _, f := range conf.Formats {
+		fmt.Fprintf(tabw, "%s\t%s\t- %s\n", f.Name, f.Description, "(defined in config)")
+	}
 	fmt.Fprintf(tabw, "%s\t%s\t- %s\n", "rdjson", "Reviewdog Diagnostic JSON Format (JSON of DiagnosticResult message)", "https://github.com/reviewdog/reviewdog")
 	fmt.Fprintf(tabw, "%s\t%s\t- %s\n", "rdjsonl", "Reviewdog Diagnostic JSONL Format (JSONL of Diagnostic message)", "https://github.com/reviewdog/reviewdog")
 	fmt.Fprintf(tabw, "%s\t%s\t- %s\n", "diff", "Unified Diff Format", "https://en.wikipedia.org/wiki/Diff#Unified_format")
 	fmt.Fprintf(tabw, "%s\t%s\t- %s\n", "checkstyle", "checkstyle XML format", "http://checkstyle.sourceforge.net/")
 	fmt.Fprintf(tabw, "%s\t%s\t- %s\n", "sarif", "SARIF JSON format", "https://sarifweb.azurewebsites.net/")
+	fmt.Fprintf(tabw, "%s\t%s\t- %s\n", "eslint-json", "ESLint JSON format (eslint -f json) with fixes", "https://eslint.org/docs/latest/use/formatters/#json")
+	fmt.Fprintf(tabw, "%s\t%s\t- %s\n", "shellcheck-json1", "ShellCheck json1 format (shellcheck -f json1) with fixes", "https://github.com/koalaman/shellcheck")
+	fmt.Fprintf(tabw, "%s\t%s\t- %s\n", "ruff-json", "Ruff JSON format (ruff check --output-format=json) with fixes", "https://docs.astral.sh/ruff/")
+	fmt.Fprintf(tabw, "%s\t%s\t- %s\n", "github-workflow-command", "GitHub Actions workflow commands (::error file=...::msg)", "https://docs.github.com/en/actions/using-workflows/workflow-commands-for-github-actions")
+	fmt.Fprintf(tabw, "%s\t%s\t- %s\n", "azure-logissue", "Azure Pipelines logging commands (##vso[task.logissue ...]msg)", "https://learn.microsoft.com/en-us/azure/devops/pipelines/scripts/logging-commands")
+	fmt.Fprintf(tabw, "%s\t%s\t- %s\n", "teamcity-inspection", "TeamCity inspection service messages (##teamcity[inspection ...])", "https://www.jetbrains.com/help/teamcity/service-messages.html#Reporting+Inspections")
+	fmt.Fprintf(tabw, "%s\t%s\t- %s\n", "lsp", "LSP PublishDiagnosticsParams in JSON", "https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textDocument_publishDiagnostics")
 	for _, f := range sortedFmts(fmts.DefinedFmts()) {
 		fmt.Fprintf(tabw, "%s\t%s\t- %s\n", f.Name, f.Description, f.URL)
 	}
@@ -638,6 +835,10 @@ func giteaClient(ctx context.Context, url, token string) (*gitea.Client, error)
 }
 
 func githubService(ctx context.Context, opt *option) (gs *githubservice.PullRequest, isPR bool, err error) {
+	if fl := failLevel(opt); opt.githubReviewEvent != githubservice.ReviewEventComment &&
+		(fl == reviewdog.FailLevelDefault || fl == reviewdog.FailLevelNone) {
+		return nil, false, fmt.Errorf("reviewdog: -github-review-event=%s requires -fail-level", opt.githubReviewEvent.String())
+	}
 	g, client, err := githubBuildInfoWithClient(ctx)
 	if err != nil {
 		return nil, false, err
@@ -660,6 +861,8 @@ func githubService(ctx context.Context, opt *option) (gs *githubservice.PullRequ
 	}
 
 	gs = githubservice.NewGitHubPullRequest(client, g.Owner, g.Repo, g.PullRequest, g.SHA, opt.level, toolName(opt))
+	gs.SetResolveOutdatedThreads(opt.githubResolveThread)
+	gs.SetReviewEvent(opt.githubReviewEvent, failLevel(opt))
 	return gs, true, nil
 }
 
@@ -951,8 +1154,24 @@ func (ss *strslice) Set(value string) error {
 	return nil
 }
 
-func projectConfig(path string) (*project.Config, error) {
-	b, err := readConf(path)
+// formatnames is a flag.Value which joins format names of repeated flags
+// by comma.
+type formatnames string
+
+func (fs *formatnames) String() string {
+	return string(*fs)
+}
+
+func (fs *formatnames) Set(value string) error {
+	if *fs != "" {
+		value = string(*fs) + "," + value
+	}
+	*fs = formatnames(value)
+	return nil
+}
+
+func projectConfig(opt *option) (*project.Config, error) {
+	b, err := readConf(opt.conf)
 	if err != nil {
 		return nil, fmt.Errorf("fail to open config: %w", err)
 	}
@@ -960,6 +1179,14 @@ func projectConfig(path string) (*project.Config, error) {
 	if err != nil {
 		return nil, fmt.Errorf("config is invalid: %w", err)
 	}
+	for _, runner := range conf.Runner {
+		if runner.Lenient == "" {
+			runner.Lenient = opt.lenient.String()
+		}
+		if runner.ColumnUnit == "" {
+			runner.ColumnUnit = opt.columnUnit.String()
+		}
+	}
 	return conf, nil
 }
 
@@ -984,11 +1211,64 @@ func readConf(conf string) ([]byte, error) {
 	return nil, errors.New(".reviewdog.yml not found")
 }
 
+// parserConfig returns config file for parser options such as user-defined
+// formats. The default config file is loaded only if it's needed by -list or
+// format names: user-defined formats and checkstyle sources. Missing or invalid
+// default config is ignored unless a user-defined format is specified.
+func parserConfig(opt *option) (*project.Config, error) {
+	if opt.conf != "" {
+		b, err := readConf(opt.conf)
+		if err != nil {
+			return nil, fmt.Errorf("fail to open config: %w", err)
+		}
+		conf, err := project.Parse(b)
+		if err != nil {
+			return nil, fmt.Errorf("config is invalid: %w", err)
+		}
+		return conf, nil
+	}
+	required, needed := false, opt.list
+	for _, name := range strings.Split(string(opt.f), ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if !parser.IsBuiltinFormat(name) {
+			required = true
+		}
+		needed = needed || name == "checkstyle"
+	}
+	if !required && !needed {
+		return &project.Config{}, nil
+	}
+	b, err := readConf("")
+	if err != nil {
+		return &project.Config{}, nil
+	}
+	conf, err := project.Parse(b)
+	if err != nil {
+		if required {
+			return nil, fmt.Errorf("config is invalid: %w", err)
+		}
+		return &project.Config{}, nil
+	}
+	return conf, nil
+}
+
 func newParserFromOpt(opt *option) (parser.Parser, error) {
+	conf, err := parserConfig(opt)
+	if err != nil {
+		return nil, err
+	}
 	p, err := parser.New(&parser.Option{
-		FormatName:  opt.f,
-		DiffStrip:   opt.fDiffStrip,
-		Errorformat: opt.efms,
+		FormatName:         string(opt.f),
+		DiffStrip:          opt.fDiffStrip,
+		SarifSkipUnchanged: opt.fSarifSkipUnchanged,
+		Lenient:            opt.lenient,
+		ColumnUnit:         opt.columnUnit,
+		Formats:            conf.Formats,
+		CheckStyleSources:  conf.CheckStyleSources,
+		Errorformat:        opt.efms,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("fail to create parser. use either -f or -efm: %w", err)
@@ -999,7 +1279,7 @@ func newParserFromOpt(opt *option) (parser.Parser, error) {
 func toolName(opt *option) string {
 	name := opt.name
 	if name == "" && opt.f != "" {
-		name = opt.f
+		name = string(opt.f)
 	}
 	return name
 }
@@ -1037,6 +1317,53 @@ func getRunnersList(opt *option, conf *project.Config) []string {
 	return []string{}
 }
 
+func webhookService(ctx context.Context, opt *option) (*webhook.Webhook, error) {
+	u := opt.webhookURL
+	if u == "" {
+		u = os.Getenv("REVIEWDOG_WEBHOOK_URL")
+	}
+	if u == "" {
+		return nil, errors.New("-webhook-url or REVIEWDOG_WEBHOOK_URL is required for webhook reporter")
+	}
+	header := make(http.Header)
+	for _, h := range opt.webhookHeaders {
+		k, v, ok := strings.Cut(h, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid -webhook-header: %q", h)
+		}
+		header.Add(strings.TrimSpace(k), strings.TrimSpace(v))
+	}
+	// Build info is optional for webhook.
+	build, _, err := cienv.GetBuildInfo()
+	if err != nil {
+		slog.DebugContext(ctx, "reviewdog: webhook payloads don't have build info", "error", err)
+		build = nil
+	}
+	wh := webhook.NewWebhook(newHTTPClient(), u, build, &webhook.Option{
+		Secret:     os.Getenv("REVIEWDOG_WEBHOOK_SECRET"),
+		Header:     header,
+		BatchSize:  opt.webhookBatchSize,
+		MaxRetries: opt.webhookMaxRetries,
+		RetryWait:  time.Second,
+	})
+	wh.SetTool(toolName(opt), opt.level)
+	return wh, nil
+}
+
+// colorEnabled returns true if w is a terminal and NO_COLOR environment
+// variable is not set (https://no-color.org/).
+func colorEnabled(w io.Writer) bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
 func localDiffService(opt *option) (reviewdog.DiffService, error) {
 	if (opt.diffCmd == "" && opt.filterMode == filter.ModeDefault) || opt.filterMode == filter.ModeNoFilter {
 		opt.filterMode = filter.ModeNoFilter
@@ -208,6 +208,33 @@ func TestRun_project(t *testing.T) {
 	})
 }
 
+func TestParserConfig(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if err := os.WriteFile(".reviewdog.yml", []byte("invalid yaml"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		opt     *option
+		wantErr bool
+	}{
+		{name: "built-in format", opt: &option{f: "golint"}},
+		{name: "checkstyle", opt: &option{f: "checkstyle"}},
+		{name: "errorformat", opt: &option{efms: strslice{"%f:%l:%m"}}},
+		{name: "user-defined format", opt: &option{f: "mylinter"}, wantErr: true},
+		{name: "user-defined format in composite", opt: &option{f: "golint,mylinter"}, wantErr: true},
+		{name: "explicit config", opt: &option{f: "golint", conf: ".reviewdog.yml"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parserConfig(tt.opt)
+			if gotErr := err != nil; gotErr != tt.wantErr {
+				t.Errorf("parserConfig() got err %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestRun_version(t *testing.T) {
 	stdout := new(bytes.Buffer)
 	if err := run(nil, stdout, &option{version: true}); err != nil {
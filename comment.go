@@ -3,6 +3,11 @@ package reviewdog
 import "context"
 
 var _ BulkCommentService = (*multiCommentService)(nil)
+var _ OldLineCommentService = (*multiCommentService)(nil)
+var _ ReportCommentService = (*reportCommentService)(nil)
+var _ NamedCommentService = (*reportCommentService)(nil)
+var _ FilteredCommentService = (*reportCommentService)(nil)
+var _ OldLineCommentService = (*reportCommentService)(nil)
 
 type multiCommentService struct {
 	services []CommentService
@@ -37,6 +42,17 @@ func (m *multiCommentService) Flush(ctx context.Context) error {
 	return nil
 }
 
+// SupportsOldLines returns true if all the services support results in the
+// old file.
+func (m *multiCommentService) SupportsOldLines() bool {
+	for _, cs := range m.services {
+		if oc, ok := cs.(OldLineCommentService); !ok || !oc.SupportsOldLines() {
+			return false
+		}
+	}
+	return true
+}
+
 func (m *multiCommentService) SetTool(toolName string, level string) {
 	for _, cs := range m.services {
 		if ncs, ok := cs.(NamedCommentService); ok {
@@ -52,3 +68,38 @@ func MultiCommentService(services ...CommentService) CommentService {
 	copy(s, services)
 	return &multiCommentService{services: s}
 }
+
+type reportCommentService struct {
+	BulkCommentService
+}
+
+func (*reportCommentService) report() {}
+
+func (r *reportCommentService) SetTool(toolName string, level string) {
+	if ncs, ok := r.BulkCommentService.(NamedCommentService); ok {
+		ncs.SetTool(toolName, level)
+	}
+}
+
+// PostFiltered posts the filtered comment if the BulkCommentService supports
+// filtered comments. Otherwise, it drops the comment.
+func (r *reportCommentService) PostFiltered(ctx context.Context, c *Comment) error {
+	if fc, ok := r.BulkCommentService.(FilteredCommentService); ok {
+		return fc.PostFiltered(ctx, c)
+	}
+	return nil
+}
+
+// SupportsOldLines returns true if the BulkCommentService supports results in
+// the old file.
+func (r *reportCommentService) SupportsOldLines() bool {
+	oc, ok := r.BulkCommentService.(OldLineCommentService)
+	return ok && oc.SupportsOldLines()
+}
+
+// NewReportCommentService returns a ReportCommentService which flushes the
+// BulkCommentService once after all runners in project config based run.
+// Useful for report writers outside of this package.
+func NewReportCommentService(s BulkCommentService) ReportCommentService {
+	return &reportCommentService{BulkCommentService: s}
+}
@@ -0,0 +1,372 @@
+package reviewdog
+
+import (
+	"bytes"
+	"context"
+	"html/template"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/reviewdog/reviewdog/diff"
+	"github.com/reviewdog/reviewdog/proto/rdf"
+)
+
+var _ ReportCommentService = &HTMLCommentWriter{}
+
+// HTMLCommentWriter writes results as a self-contained static HTML report
+// which has a summary, code excerpts around each result, suggestion previews
+// and client-side filtering. It doesn't use any external assets.
+type HTMLCommentWriter struct {
+	w        io.Writer
+	comments []*Comment
+	// readFile reads source file content. Replaceable for testing.
+	readFile func(path string) ([]byte, error)
+}
+
+func NewHTMLCommentWriter(w io.Writer) *HTMLCommentWriter {
+	return &HTMLCommentWriter{w: w, readFile: os.ReadFile}
+}
+
+func (cw *HTMLCommentWriter) Post(_ context.Context, c *Comment) error {
+	cw.comments = append(cw.comments, c)
+	return nil
+}
+
+func (*HTMLCommentWriter) ShouldPrependGitRelDir() bool { return false }
+
+func (*HTMLCommentWriter) report() {}
+
+// number of lines around results in code excerpts.
+const htmlExcerptContext = 2
+
+type htmlReport struct {
+	Total      int
+	Tools      []*htmlCount
+	Severities []*htmlCount
+	Rules      []*htmlCount
+	Files      []*htmlFile
+}
+
+type htmlCount struct {
+	Name  string
+	Tool  string // Only for rules.
+	URL   string // Only for rules.
+	Count int
+}
+
+type htmlFile struct {
+	Path     string
+	Findings []*htmlFinding
+}
+
+type htmlFinding struct {
+	Tool        string
+	Severity    string
+	Rule        string
+	RuleURL     string
+	Message     string
+	Line        int32
+	Column      int32
+	Excerpt     []*codeLine
+	Suggestions [][]*codeLine
+}
+
+type codeLine struct {
+	Num       int
+	Type      string // "add", "del" or "" for diff lines.
+	Text      string
+	Highlight bool
+}
+
+func (cw *HTMLCommentWriter) Flush(_ context.Context) error {
+	report := &htmlReport{Total: len(cw.comments)}
+	srcs := make(map[string][]string)
+	sourceLines := func(path string) []string {
+		if lines, ok := srcs[path]; ok {
+			return lines
+		}
+		var lines []string
+		if b, err := cw.readFile(path); err == nil {
+			lines = strings.Split(string(b), "\n")
+		}
+		srcs[path] = lines
+		return lines
+	}
+	tools := make(map[string]*htmlCount)
+	severities := make(map[string]*htmlCount)
+	rules := make(map[string]*htmlCount)
+	count := func(m map[string]*htmlCount, key string, c *htmlCount) {
+		if _, ok := m[key]; !ok {
+			m[key] = c
+		}
+		m[key].Count++
+	}
+	files := make(map[string]*htmlFile)
+	for _, c := range cw.comments {
+		d := c.Result.Diagnostic
+		toolName := d.GetSource().GetName()
+		if toolName == "" {
+			toolName = c.ToolName
+		}
+		path := d.GetLocation().GetPath()
+		start := d.GetLocation().GetRange().GetStart()
+		f := &htmlFinding{
+			Tool:     toolName,
+			Severity: severityName(d.GetSeverity()),
+			Rule:     d.GetCode().GetValue(),
+			RuleURL:  d.GetCode().GetUrl(),
+			Message:  d.GetMessage(),
+			Line:     start.GetLine(),
+			Column:   start.GetColumn(),
+		}
+		lines := sourceLines(path)
+		f.Excerpt = htmlExcerpt(lines, d.GetLocation().GetRange())
+		for _, s := range d.GetSuggestions() {
+			if preview := suggestionPreview(lines, s); preview != nil {
+				f.Suggestions = append(f.Suggestions, preview)
+			}
+		}
+
+		count(tools, toolName, &htmlCount{Name: toolName})
+		count(severities, f.Severity, &htmlCount{Name: f.Severity})
+		if f.Rule != "" {
+			count(rules, toolName+"\n"+f.Rule, &htmlCount{Name: f.Rule, Tool: toolName, URL: f.RuleURL})
+		}
+		if _, ok := files[path]; !ok {
+			files[path] = &htmlFile{Path: path}
+			report.Files = append(report.Files, files[path])
+		}
+		files[path].Findings = append(files[path].Findings, f)
+	}
+	report.Tools = sortedCounts(tools)
+	report.Severities = sortedCounts(severities)
+	report.Rules = sortedCounts(rules)
+	sort.Slice(report.Files, func(i, j int) bool {
+		return report.Files[i].Path < report.Files[j].Path
+	})
+	for _, f := range report.Files {
+		sort.SliceStable(f.Findings, func(i, j int) bool {
+			if f.Findings[i].Line != f.Findings[j].Line {
+				return f.Findings[i].Line < f.Findings[j].Line
+			}
+			return f.Findings[i].Column < f.Findings[j].Column
+		})
+	}
+	cw.comments = nil
+	return htmlReportTemplate.Execute(cw.w, report)
+}
+
+func sortedCounts(m map[string]*htmlCount) []*htmlCount {
+	cs := make([]*htmlCount, 0, len(m))
+	for _, c := range m {
+		cs = append(cs, c)
+	}
+	sort.Slice(cs, func(i, j int) bool {
+		if cs[i].Count != cs[j].Count {
+			return cs[i].Count > cs[j].Count
+		}
+		if cs[i].Tool != cs[j].Tool {
+			return cs[i].Tool < cs[j].Tool
+		}
+		return cs[i].Name < cs[j].Name
+	})
+	return cs
+}
+
+func severityName(s rdf.Severity) string {
+	switch s {
+	case rdf.Severity_ERROR:
+		return "error"
+	case rdf.Severity_WARNING:
+		return "warning"
+	case rdf.Severity_INFO:
+		return "info"
+	default:
+		return "unknown"
+	}
+}
+
+// htmlExcerpt returns source lines around rng. lines is 0-indexed.
+func htmlExcerpt(lines []string, rng *rdf.Range) []*codeLine {
+	start := int(rng.GetStart().GetLine())
+	if start <= 0 || start > len(lines) {
+		return nil
+	}
+	end := int(rng.GetEnd().GetLine())
+	if end < start {
+		end = start
+	}
+	var excerpt []*codeLine
+	for l := max(start-htmlExcerptContext, 1); l <= min(end+htmlExcerptContext, len(lines)); l++ {
+		excerpt = append(excerpt, &codeLine{
+			Num:       l,
+			Text:      lines[l-1],
+			Highlight: start <= l && l <= end,
+		})
+	}
+	return excerpt
+}
+
+// suggestionPreview returns diff lines of the suggestion applied to lines.
+// Deleted and unchanged lines have line numbers.
+func suggestionPreview(lines []string, s *rdf.Suggestion) []*codeLine {
+	start, end := s.GetRange().GetStart(), s.GetRange().GetEnd()
+	startLine, endLine := int(start.GetLine()), int(end.GetLine())
+	if endLine == 0 {
+		endLine = startLine
+	}
+	if startLine <= 0 || endLine < startLine || endLine > len(lines) {
+		return nil
+	}
+	old := strings.Join(lines[startLine-1:endLine], "\n")
+	text := s.GetText()
+	if start.GetColumn() > 0 || end.GetColumn() > 0 {
+		// Non line based suggestion.
+		startContent, endContent := lines[startLine-1], lines[endLine-1]
+		sc := min(max(int(start.GetColumn())-1, 0), len(startContent))
+		ec := min(max(int(end.GetColumn())-1, 0), len(endContent))
+		text = startContent[:sc] + text + endContent[ec:]
+	}
+	fdiff, err := diff.ParseFile(bytes.NewReader(diff.Unified("a", "b", []byte(old+"\n"), []byte(text+"\n"))))
+	if err != nil || fdiff == nil {
+		return nil
+	}
+	var preview []*codeLine
+	for _, h := range fdiff.Hunks {
+		for _, l := range h.Lines {
+			hl := &codeLine{Text: l.Content}
+			switch l.Type {
+			case diff.LineAdded:
+				hl.Type = "add"
+			case diff.LineDeleted:
+				hl.Type = "del"
+				hl.Num = l.LnumOld + startLine - 1
+			default:
+				hl.Num = l.LnumOld + startLine - 1
+			}
+			preview = append(preview, hl)
+		}
+	}
+	return preview
+}
+
+var htmlReportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>reviewdog report</title>
+<style>
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif; margin: 0 auto; max-width: 1200px; padding: 1em; color: #1f2328; }
+table.summary { border-collapse: collapse; margin: 0 2em 1em 0; display: inline-table; vertical-align: top; }
+table.summary th, table.summary td { border: 1px solid #d0d7de; padding: 2px 8px; text-align: left; }
+#filters { position: sticky; top: 0; background: #fff; padding: 0.5em 0; border-bottom: 1px solid #d0d7de; }
+.file h2 { font-size: 1.1em; background: #f6f8fa; padding: 4px 8px; border: 1px solid #d0d7de; }
+.finding { margin: 0 0 1em 1em; }
+.message { white-space: pre-wrap; }
+.severity { font-weight: bold; text-transform: uppercase; font-size: 0.8em; padding: 1px 4px; border-radius: 4px; }
+.severity-error { background: #ffebe9; color: #cf222e; }
+.severity-warning { background: #fff8c5; color: #9a6700; }
+.severity-info { background: #ddf4ff; color: #0969da; }
+.severity-unknown { background: #f6f8fa; color: #57606a; }
+table.code { border-collapse: collapse; font-family: ui-monospace, SFMono-Regular, Menlo, monospace; font-size: 0.85em; width: 100%; margin-top: 4px; }
+table.code td { padding: 0 8px; white-space: pre; }
+table.code td.num { color: #57606a; text-align: right; width: 1%; user-select: none; }
+tr.highlight { background: #fff8c5; }
+tr.add { background: #e6ffec; }
+tr.del { background: #ffebe9; }
+.hidden { display: none; }
+</style>
+</head>
+<body>
+<h1>reviewdog report</h1>
+<p>{{.Total}} result(s) in {{len .Files}} file(s).</p>
+<table class="summary">
+<tr><th>Tool</th><th>Results</th></tr>
+{{- range .Tools}}
+<tr><td>{{.Name}}</td><td>{{.Count}}</td></tr>
+{{- end}}
+</table>
+<table class="summary">
+<tr><th>Severity</th><th>Results</th></tr>
+{{- range .Severities}}
+<tr><td><span class="severity severity-{{.Name}}">{{.Name}}</span></td><td>{{.Count}}</td></tr>
+{{- end}}
+</table>
+{{- if .Rules}}
+<table class="summary">
+<tr><th>Tool</th><th>Rule</th><th>Results</th></tr>
+{{- range .Rules}}
+<tr><td>{{.Tool}}</td><td>{{if .URL}}<a href="{{.URL}}">{{.Name}}</a>{{else}}{{.Name}}{{end}}</td><td>{{.Count}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+<div id="filters">
+<label>Tool <select id="filter-tool"><option value="">all</option>{{range .Tools}}<option>{{.Name}}</option>{{end}}</select></label>
+<label>Severity <select id="filter-severity"><option value="">all</option>{{range .Severities}}<option>{{.Name}}</option>{{end}}</select></label>
+<label>Search <input id="filter-text" type="search" placeholder="path, rule or message"></label>
+<span id="filter-count"></span>
+</div>
+{{- range .Files}}
+<section class="file">
+<h2>{{.Path}}</h2>
+{{- range .Findings}}
+<div class="finding" data-tool="{{.Tool}}" data-severity="{{.Severity}}">
+<div><span class="severity severity-{{.Severity}}">{{.Severity}}</span>
+{{if .Line}}L{{.Line}}{{if .Column}}:{{.Column}}{{end}} {{end}}[{{.Tool}}]
+{{- if .Rule}} {{if .RuleURL}}<a href="{{.RuleURL}}">{{.Rule}}</a>{{else}}{{.Rule}}{{end}}{{end}}</div>
+<div class="message">{{.Message}}</div>
+{{- if .Excerpt}}
+<table class="code">
+{{- range .Excerpt}}
+<tr{{if .Highlight}} class="highlight"{{end}}><td class="num">{{.Num}}</td><td>{{.Text}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- range .Suggestions}}
+<div>Suggestion:</div>
+<table class="code suggestion">
+{{- range .}}
+<tr{{if .Type}} class="{{.Type}}"{{end}}><td class="num">{{if .Num}}{{.Num}}{{end}}</td><td>{{if eq .Type "add"}}+{{else if eq .Type "del"}}-{{else}} {{end}}{{.Text}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+</div>
+{{- end}}
+</section>
+{{- end}}
+<script>
+(function() {
+  var tool = document.getElementById("filter-tool");
+  var severity = document.getElementById("filter-severity");
+  var text = document.getElementById("filter-text");
+  var count = document.getElementById("filter-count");
+  function update() {
+    var q = text.value.toLowerCase();
+    var shown = 0;
+    document.querySelectorAll(".file").forEach(function(file) {
+      var path = file.querySelector("h2").textContent.toLowerCase();
+      var visible = 0;
+      file.querySelectorAll(".finding").forEach(function(f) {
+        var ok = (!tool.value || f.dataset.tool === tool.value) &&
+          (!severity.value || f.dataset.severity === severity.value) &&
+          (!q || path.indexOf(q) >= 0 || f.textContent.toLowerCase().indexOf(q) >= 0);
+        f.classList.toggle("hidden", !ok);
+        if (ok) { visible++; }
+      });
+      file.classList.toggle("hidden", visible === 0);
+      shown += visible;
+    });
+    count.textContent = shown + " result(s) shown";
+  }
+  tool.addEventListener("change", update);
+  severity.addEventListener("change", update);
+  text.addEventListener("input", update);
+  update();
+})();
+</script>
+</body>
+</html>
+`))
@@ -0,0 +1,151 @@
+package reviewdog
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/reviewdog/reviewdog/filter"
+	"github.com/reviewdog/reviewdog/proto/rdf"
+)
+
+func TestHTMLCommentWriter_Flush(t *testing.T) {
+	src := "package main\n\nfunc main() {\n\tx := 1\n}\n"
+	comments := []*Comment{
+		{
+			Result: &filter.FilteredDiagnostic{
+				Diagnostic: &rdf.Diagnostic{
+					Location: &rdf.Location{
+						Path:  "main.go",
+						Range: &rdf.Range{Start: &rdf.Position{Line: 4, Column: 2}},
+					},
+					Message:  "x declared and not used <x>",
+					Severity: rdf.Severity_ERROR,
+					Code:     &rdf.Code{Value: "unused", Url: "https://example.com/unused"},
+					Suggestions: []*rdf.Suggestion{
+						{
+							Range: &rdf.Range{Start: &rdf.Position{Line: 4}, End: &rdf.Position{Line: 4}},
+							Text:  "\t_ = 1",
+						},
+					},
+				},
+			},
+			ToolName: "govet",
+		},
+		{
+			Result: &filter.FilteredDiagnostic{
+				Diagnostic: &rdf.Diagnostic{
+					Location: &rdf.Location{Path: "missing.go"},
+					Message:  "file level",
+				},
+			},
+			ToolName: "golint",
+		},
+	}
+	buf := new(bytes.Buffer)
+	cw := NewHTMLCommentWriter(buf)
+	cw.readFile = func(path string) ([]byte, error) {
+		if path == "main.go" {
+			return []byte(src), nil
+		}
+		return nil, errors.New("not found")
+	}
+	for _, c := range comments {
+		if err := cw.Post(context.Background(), c); err != nil {
+			t.Error(err)
+		}
+	}
+	if err := cw.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	got := buf.String()
+	for _, want := range []string{
+		"<p>2 result(s) in 2 file(s).</p>",
+		`<h2>main.go</h2>`,
+		`<h2>missing.go</h2>`,
+		`<div class="finding" data-tool="govet" data-severity="error">`,
+		`<div class="finding" data-tool="golint" data-severity="unknown">`,
+		`<a href="https://example.com/unused">unused</a>`,
+		"x declared and not used &lt;x&gt;",
+		`<tr class="highlight"><td class="num">4</td><td>	x := 1</td></tr>`,
+		`<tr class="del"><td class="num">4</td><td>-	x := 1</td></tr>`,
+		`<tr class="add"><td class="num"></td><td>+	_ = 1</td></tr>`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("report doesn't contain %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "<x>") {
+		t.Errorf("message is not escaped:\n%s", got)
+	}
+}
+
+func TestHTMLExcerpt(t *testing.T) {
+	lines := []string{"1", "2", "3", "4", "5", "6", "7"}
+	rng := &rdf.Range{Start: &rdf.Position{Line: 4}, End: &rdf.Position{Line: 5}}
+	want := []*codeLine{
+		{Num: 2, Text: "2"},
+		{Num: 3, Text: "3"},
+		{Num: 4, Text: "4", Highlight: true},
+		{Num: 5, Text: "5", Highlight: true},
+		{Num: 6, Text: "6"},
+		{Num: 7, Text: "7"},
+	}
+	if diff := cmp.Diff(want, htmlExcerpt(lines, rng)); diff != "" {
+		t.Errorf("htmlExcerpt() diff (-want +got):\n%s", diff)
+	}
+	if got := htmlExcerpt(lines, &rdf.Range{Start: &rdf.Position{Line: 8}}); got != nil {
+		t.Errorf("htmlExcerpt() for out of range line = %v, want nil", got)
+	}
+}
+
+func TestSuggestionPreview(t *testing.T) {
+	lines := []string{"a", "foo(bar)", "c"}
+	tests := []struct {
+		name string
+		s    *rdf.Suggestion
+		want []*codeLine
+	}{
+		{
+			name: "line based",
+			s: &rdf.Suggestion{
+				Range: &rdf.Range{Start: &rdf.Position{Line: 1}, End: &rdf.Position{Line: 2}},
+				Text:  "a\nfoo(baz)",
+			},
+			want: []*codeLine{
+				{Num: 1, Text: "a"},
+				{Num: 2, Type: "del", Text: "foo(bar)"},
+				{Type: "add", Text: "foo(baz)"},
+			},
+		},
+		{
+			name: "non line based",
+			s: &rdf.Suggestion{
+				Range: &rdf.Range{Start: &rdf.Position{Line: 2, Column: 5}, End: &rdf.Position{Line: 2, Column: 8}},
+				Text:  "baz",
+			},
+			want: []*codeLine{
+				{Num: 2, Type: "del", Text: "foo(bar)"},
+				{Type: "add", Text: "foo(baz)"},
+			},
+		},
+		{
+			name: "out of range",
+			s: &rdf.Suggestion{
+				Range: &rdf.Range{Start: &rdf.Position{Line: 4}},
+				Text:  "d",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, suggestionPreview(lines, tt.s)); diff != "" {
+				t.Errorf("suggestionPreview() diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
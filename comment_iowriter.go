@@ -2,12 +2,20 @@ package reviewdog
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"encoding/xml"
 	"fmt"
 	"io"
+	"slices"
+	"sort"
 
 	"github.com/haya14busa/go-sarif/sarif"
+	"github.com/reviewdog/reviewdog/commands"
+	"github.com/reviewdog/reviewdog/parser"
 	"github.com/reviewdog/reviewdog/proto/rdf"
+	"github.com/reviewdog/reviewdog/service/serviceutil"
 	"google.golang.org/protobuf/encoding/protojson"
 )
 
@@ -157,17 +165,24 @@ func (cw *RDJSONCommentWriter) Flush(_ context.Context) error {
 	return nil
 }
 
-var _ CommentService = &SARIFCommentWriter{}
+var _ ReportCommentService = &SARIFCommentWriter{}
+var _ NamedCommentService = &SARIFCommentWriter{}
 
-// SARIFCommentWriter
+// SARIFCommentWriter writes results in SARIF format. It writes one run per
+// tool (runner) so that SARIF viewers such as GitHub code scanning can track
+// results of each tool separately. Tools without any findings have a run
+// with empty results so that GitHub code scanning closes their alerts.
 type SARIFCommentWriter struct {
 	w        io.Writer
 	comments []*Comment
 	toolName string
+	tools    []string
 }
 
 func NewSARIFCommentWriter(w io.Writer, toolName string) *SARIFCommentWriter {
-	return &SARIFCommentWriter{w: w, toolName: toolName}
+	cw := &SARIFCommentWriter{w: w, toolName: toolName}
+	cw.SetTool(toolName, "")
+	return cw
 }
 
 func (cw *SARIFCommentWriter) Post(_ context.Context, c *Comment) error {
@@ -177,17 +192,63 @@ func (cw *SARIFCommentWriter) Post(_ context.Context, c *Comment) error {
 
 func (*SARIFCommentWriter) ShouldPrependGitRelDir() bool { return false }
 
+// SetTool registers the tool so that it has a run even if it has no findings.
+func (cw *SARIFCommentWriter) SetTool(toolName string, _ string) {
+	if toolName != "" && !slices.Contains(cw.tools, toolName) {
+		cw.tools = append(cw.tools, toolName)
+	}
+}
+
+func (*SARIFCommentWriter) report() {}
+
+// sarifFingerprintKey is the key of partialFingerprints which reviewdog
+// calculates.
+const sarifFingerprintKey = "reviewdog/v1"
+
 func (cw *SARIFCommentWriter) Flush(_ context.Context) error {
-	run := sarif.Run{
-		Tool: sarif.Tool{
-			Driver: sarif.ToolComponent{
-				Name:  cw.toolName,
-				Rules: make([]sarif.ReportingDescriptor, 0),
+	runs := make(map[string]*sarif.Run)
+	ruleIndexes := make(map[string]map[string]int64)
+	run := func(toolName string) *sarif.Run {
+		if r, ok := runs[toolName]; ok {
+			return r
+		}
+		r := &sarif.Run{
+			Tool: sarif.Tool{
+				Driver: sarif.ToolComponent{
+					Name:  toolName,
+					Rules: make([]sarif.ReportingDescriptor, 0),
+				},
 			},
-		},
+			// Distinguish runs of different tools in the same file.
+			AutomationDetails: &sarif.RunAutomationDetails{
+				ID: sarif.String(toolName + "/"),
+			},
+			Conversion: &sarif.Conversion{
+				Tool: sarif.Tool{
+					Driver: sarif.ToolComponent{
+						Name:           "reviewdog",
+						Version:        sarif.String(commands.Version),
+						InformationURI: sarif.String("https://github.com/reviewdog/reviewdog"),
+					},
+				},
+			},
+		}
+		runs[toolName] = r
+		ruleIndexes[toolName] = make(map[string]int64)
+		return r
+	}
+	for _, tool := range cw.tools {
+		run(tool)
 	}
-	seenRules := make(map[string]bool)
 	for _, c := range cw.comments {
+		toolName := c.ToolName
+		if toolName == "" {
+			toolName = cw.toolName
+		}
+		r := run(toolName)
+		if url := c.Result.Diagnostic.GetSource().GetUrl(); url != "" && r.Tool.Driver.InformationURI == nil {
+			r.Tool.Driver.InformationURI = sarif.String(url)
+		}
 		result := sarif.Result{
 			Message: sarif.Message{
 				Text: sarif.String(c.Result.Diagnostic.Message),
@@ -195,16 +256,19 @@ func (cw *SARIFCommentWriter) Flush(_ context.Context) error {
 		}
 		if code := c.Result.Diagnostic.GetCode(); code.GetValue() != "" {
 			result.RuleID = sarif.String(code.GetValue())
-			if seen := seenRules[code.GetValue()]; !seen {
-				seenRules[code.GetValue()] = true
+			index, seen := ruleIndexes[toolName][code.GetValue()]
+			if !seen {
+				index = int64(len(r.Tool.Driver.Rules))
+				ruleIndexes[toolName][code.GetValue()] = index
 				rd := sarif.ReportingDescriptor{
 					ID: code.GetValue(),
 				}
 				if code.GetUrl() != "" {
 					rd.HelpURI = sarif.String(code.GetUrl())
 				}
-				run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, rd)
+				r.Tool.Driver.Rules = append(r.Tool.Driver.Rules, rd)
 			}
+			result.RuleIndex = sarif.Int64(index)
 		}
 		level := severity2level(c.Result.Diagnostic.GetSeverity())
 		if level != sarif.None {
@@ -216,7 +280,7 @@ func (cw *SARIFCommentWriter) Flush(_ context.Context) error {
 		result.Locations = []sarif.Location{{
 			PhysicalLocation: &sarif.PhysicalLocation{
 				ArtifactLocation: &artifactLoc,
-				Region:           range2region(c.Result.Diagnostic.GetLocation().GetRange()),
+				Region:           locationRegion(c.Result.Diagnostic.GetLocation().GetRange()),
 			},
 		}}
 		if len(c.Result.Diagnostic.GetSuggestions()) > 0 {
@@ -239,13 +303,14 @@ func (cw *SARIFCommentWriter) Flush(_ context.Context) error {
 		}
 		if len(c.Result.Diagnostic.GetRelatedLocations()) > 0 {
 			result.RelatedLocations = make([]sarif.Location, 0)
-			for _, relLoc := range c.Result.Diagnostic.GetRelatedLocations() {
+			for i, relLoc := range c.Result.Diagnostic.GetRelatedLocations() {
 				result.RelatedLocations = append(result.RelatedLocations, sarif.Location{
+					ID: sarif.Int64(int64(i)),
 					PhysicalLocation: &sarif.PhysicalLocation{
 						ArtifactLocation: &sarif.ArtifactLocation{
 							URI: sarif.String(relLoc.GetLocation().GetPath()),
 						},
-						Region: range2region(relLoc.GetLocation().GetRange()),
+						Region: locationRegion(relLoc.GetLocation().GetRange()),
 					},
 					Message: &sarif.Message{
 						Text: sarif.String(relLoc.Message),
@@ -253,13 +318,81 @@ func (cw *SARIFCommentWriter) Flush(_ context.Context) error {
 				})
 			}
 		}
-		run.Results = append(run.Results, result)
+		fingerprint, err := serviceutil.Fingerprint(c.Result.Diagnostic)
+		if err != nil {
+			return err
+		}
+		result.PartialFingerprints = map[string]string{sarifFingerprintKey: fingerprint}
+		for k, v := range c.Result.Diagnostic.GetFingerprints() {
+			result.PartialFingerprints[k] = v
+		}
+		r.Results = append(r.Results, result)
+	}
+	if len(runs) == 0 {
+		// SARIF requires at least one run.
+		run(cw.toolName)
 	}
 	slf := sarif.NewSarif()
-	slf.Runs = []sarif.Run{run}
+	for _, r := range runs {
+		slf.Runs = append(slf.Runs, *r)
+	}
+	sort.Slice(slf.Runs, func(i, j int) bool {
+		return slf.Runs[i].Tool.Driver.Name < slf.Runs[j].Tool.Driver.Name
+	})
+	cw.comments = nil
+	log, err := sarifLogFields(slf)
+	if err != nil {
+		return err
+	}
 	encoder := json.NewEncoder(cw.w)
 	encoder.SetIndent("", "  ")
-	return encoder.Encode(slf)
+	return encoder.Encode(log)
+}
+
+// sarifLogFields returns JSON fields of the SARIF log whose runs always have
+// results. sarif.Run omits empty results, but missing results mean that
+// results were not determined while empty results mean no findings.
+func sarifLogFields(slf *sarif.Sarif) (map[string]json.RawMessage, error) {
+	runs := make([]map[string]json.RawMessage, 0, len(slf.Runs))
+	for _, r := range slf.Runs {
+		run, err := jsonFields(r)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := run["results"]; !ok {
+			run["results"] = json.RawMessage("[]")
+		}
+		runs = append(runs, run)
+	}
+	log, err := jsonFields(slf)
+	if err != nil {
+		return nil, err
+	}
+	if log["runs"], err = json.Marshal(runs); err != nil {
+		return nil, err
+	}
+	return log, nil
+}
+
+// jsonFields returns fields of v in JSON object.
+func jsonFields(v any) (map[string]json.RawMessage, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// locationRegion returns region of rng, or nil for file level locations.
+func locationRegion(rng *rdf.Range) *sarif.Region {
+	if rng.GetStart().GetLine() == 0 && rng.GetStart().GetColumn() == 0 {
+		return nil
+	}
+	return range2region(rng)
 }
 
 func range2region(rng *rdf.Range) *sarif.Region {
@@ -289,7 +422,381 @@ func severity2level(s rdf.Severity) sarif.Level {
 		return sarif.Error
 	case rdf.Severity_WARNING:
 		return sarif.Warning
+	case rdf.Severity_INFO:
+		return sarif.Note
 	default:
 		return sarif.None
 	}
 }
+
+var _ ReportCommentService = &CodeQualityCommentWriter{}
+
+// CodeQualityCommentWriter writes results as GitLab Code Quality report, which
+// is a subset of Code Climate JSON format.
+//
+// References:
+//   - https://docs.gitlab.com/ci/testing/code_quality/#code-quality-report-format
+//   - https://github.com/codeclimate/platform/blob/master/spec/analyzers/SPEC.md#data-types
+type CodeQualityCommentWriter struct {
+	w        io.Writer
+	comments []*Comment
+}
+
+func NewCodeQualityCommentWriter(w io.Writer) *CodeQualityCommentWriter {
+	return &CodeQualityCommentWriter{w: w}
+}
+
+// CodeQualityIssue represents an issue of GitLab Code Quality report.
+type CodeQualityIssue struct {
+	Description string              `json:"description"`
+	CheckName   string              `json:"check_name"`
+	Fingerprint string              `json:"fingerprint"`
+	Severity    string              `json:"severity"`
+	EngineName  string              `json:"engine_name,omitempty"`
+	Location    CodeQualityLocation `json:"location"`
+}
+
+// CodeQualityLocation represents a location of CodeQualityIssue.
+type CodeQualityLocation struct {
+	Path  string           `json:"path"`
+	Lines CodeQualityLines `json:"lines"`
+}
+
+// CodeQualityLines represents 1-based line range of CodeQualityLocation.
+type CodeQualityLines struct {
+	Begin int32 `json:"begin"`
+	End   int32 `json:"end,omitempty"`
+}
+
+func (cw *CodeQualityCommentWriter) Post(_ context.Context, c *Comment) error {
+	cw.comments = append(cw.comments, c)
+	return nil
+}
+
+// Paths in Code Quality report should be relative to the repository root.
+func (*CodeQualityCommentWriter) ShouldPrependGitRelDir() bool { return true }
+
+func (*CodeQualityCommentWriter) report() {}
+
+func (cw *CodeQualityCommentWriter) Flush(_ context.Context) error {
+	issues := make([]*CodeQualityIssue, 0, len(cw.comments))
+	// Number of issues which have the same fingerprint seed.
+	seen := make(map[string]int)
+	for _, c := range cw.comments {
+		d := c.Result.Diagnostic
+		toolName := d.GetSource().GetName()
+		if toolName == "" {
+			toolName = c.ToolName
+		}
+		checkName := d.GetCode().GetValue()
+		if checkName == "" {
+			checkName = toolName
+		}
+		fingerprint, err := codeQualityFingerprint(toolName, d, seen)
+		if err != nil {
+			return err
+		}
+		begin := d.GetLocation().GetRange().GetStart().GetLine()
+		if begin <= 0 {
+			begin = 1 // File level issue.
+		}
+		end := d.GetLocation().GetRange().GetEnd().GetLine()
+		if end < begin {
+			end = begin
+		}
+		issues = append(issues, &CodeQualityIssue{
+			Description: d.GetMessage(),
+			CheckName:   checkName,
+			Fingerprint: fingerprint,
+			Severity:    severity2codeQuality(d.GetSeverity()),
+			EngineName:  toolName,
+			Location: CodeQualityLocation{
+				Path:  d.GetLocation().GetPath(),
+				Lines: CodeQualityLines{Begin: begin, End: end},
+			},
+		})
+	}
+	cw.comments = nil
+	encoder := json.NewEncoder(cw.w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(issues)
+}
+
+// codeQualityFingerprint returns a fingerprint which is stable even if the
+// line of the diagnostic changes, so that GitLab can compare issues between
+// the source and the target branch. Identical issues in the same file are
+// distinguished by order of appearance.
+func codeQualityFingerprint(toolName string, d *rdf.Diagnostic, seen map[string]int) (string, error) {
+	seed := fmt.Sprintf("%s\n%s\n%s\n%s", toolName, d.GetLocation().GetPath(), d.GetCode().GetValue(), d.GetMessage())
+	if len(d.GetFingerprints()) > 0 {
+		fp, err := serviceutil.Fingerprint(d)
+		if err != nil {
+			return "", err
+		}
+		seed = toolName + "\n" + fp
+	}
+	n := seen[seed]
+	seen[seed]++
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s\n%d", seed, n)))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func severity2codeQuality(s rdf.Severity) string {
+	switch s {
+	case rdf.Severity_ERROR:
+		return "critical"
+	case rdf.Severity_WARNING:
+		return "major"
+	case rdf.Severity_INFO:
+		return "minor"
+	default:
+		return "info"
+	}
+}
+
+var _ ReportCommentService = &JUnitCommentWriter{}
+var _ NamedCommentService = &JUnitCommentWriter{}
+
+// JUnitCommentWriter writes results as JUnit XML report. It writes one
+// testsuite per tool and one failing testcase per diagnostic. Tools without
+// any findings have a passing testcase.
+type JUnitCommentWriter struct {
+	w        io.Writer
+	comments []*Comment
+	tools    []string
+}
+
+func NewJUnitCommentWriter(w io.Writer, toolName string) *JUnitCommentWriter {
+	cw := &JUnitCommentWriter{w: w}
+	cw.SetTool(toolName, "")
+	return cw
+}
+
+// JUnitTestSuites represents <testsuites> of JUnit XML.
+type JUnitTestSuites struct {
+	XMLName  xml.Name          `xml:"testsuites"`
+	Name     string            `xml:"name,attr"`
+	Tests    int               `xml:"tests,attr"`
+	Failures int               `xml:"failures,attr"`
+	Suites   []*JUnitTestSuite `xml:"testsuite"`
+}
+
+// JUnitTestSuite represents <testsuite> of JUnit XML.
+type JUnitTestSuite struct {
+	Name      string           `xml:"name,attr"`
+	Tests     int              `xml:"tests,attr"`
+	Failures  int              `xml:"failures,attr"`
+	TestCases []*JUnitTestCase `xml:"testcase"`
+}
+
+// JUnitTestCase represents <testcase> of JUnit XML.
+type JUnitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Failure   *JUnitFailure `xml:"failure,omitempty"`
+}
+
+// JUnitFailure represents <failure> of JUnit XML.
+type JUnitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+func (cw *JUnitCommentWriter) Post(_ context.Context, c *Comment) error {
+	cw.SetTool(c.ToolName, "")
+	cw.comments = append(cw.comments, c)
+	return nil
+}
+
+func (*JUnitCommentWriter) ShouldPrependGitRelDir() bool { return false }
+
+// SetTool registers the tool so that it has a passing testcase if it has no
+// findings.
+func (cw *JUnitCommentWriter) SetTool(toolName string, _ string) {
+	if toolName != "" && !slices.Contains(cw.tools, toolName) {
+		cw.tools = append(cw.tools, toolName)
+	}
+}
+
+func (*JUnitCommentWriter) report() {}
+
+func (cw *JUnitCommentWriter) Flush(_ context.Context) error {
+	suites := make(map[string]*JUnitTestSuite)
+	suite := func(name string) *JUnitTestSuite {
+		if s, ok := suites[name]; ok {
+			return s
+		}
+		s := &JUnitTestSuite{Name: name}
+		suites[name] = s
+		return s
+	}
+	for _, tool := range cw.tools {
+		suite(tool)
+	}
+	for _, c := range cw.comments {
+		d := c.Result.Diagnostic
+		toolName := c.ToolName
+		if toolName == "" {
+			toolName = d.GetSource().GetName()
+		}
+		s := suite(toolName)
+		s.TestCases = append(s.TestCases, junitTestCase(toolName, d))
+		s.Failures++
+	}
+	report := &JUnitTestSuites{Name: "reviewdog"}
+	for _, s := range suites {
+		if len(s.TestCases) == 0 {
+			s.TestCases = []*JUnitTestCase{{ClassName: s.Name, Name: s.Name}}
+		}
+		s.Tests = len(s.TestCases)
+		report.Tests += s.Tests
+		report.Failures += s.Failures
+		report.Suites = append(report.Suites, s)
+	}
+	sort.Slice(report.Suites, func(i, j int) bool {
+		return report.Suites[i].Name < report.Suites[j].Name
+	})
+	cw.comments = nil
+	if _, err := io.WriteString(cw.w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(cw.w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+	_, err := io.WriteString(cw.w, "\n")
+	return err
+}
+
+// junitTestCase returns a failed testcase of the diagnostic. Its name is the
+// code prefixed with the source if the source differs from the tool (e.g.
+// eslint.semi of a runner which runs ESLint and other linters).
+func junitTestCase(toolName string, d *rdf.Diagnostic) *JUnitTestCase {
+	name := d.GetCode().GetValue()
+	if source := d.GetSource().GetName(); source != "" && source != toolName {
+		if name == "" {
+			name = source
+		} else {
+			name = source + "." + name
+		}
+	}
+	if name == "" {
+		name = toolName
+	}
+	start := d.GetLocation().GetRange().GetStart()
+	pos := d.GetLocation().GetPath()
+	if start.GetLine() > 0 {
+		name += fmt.Sprintf(":%d", start.GetLine())
+		pos += fmt.Sprintf(":%d", start.GetLine())
+		if start.GetColumn() > 0 {
+			pos += fmt.Sprintf(":%d", start.GetColumn())
+		}
+	}
+	text := fmt.Sprintf("%s: %s", pos, d.GetMessage())
+	if url := d.GetCode().GetUrl(); url != "" {
+		text += "\n" + url
+	}
+	return &JUnitTestCase{
+		ClassName: d.GetLocation().GetPath(),
+		Name:      name,
+		Failure: &JUnitFailure{
+			Message: d.GetMessage(),
+			Type:    d.GetSeverity().String(),
+			Text:    text,
+		},
+	}
+}
+
+var _ ReportCommentService = &CheckStyleCommentWriter{}
+
+// CheckStyleCommentWriter writes results as checkstyle XML report grouped by
+// file.
+type CheckStyleCommentWriter struct {
+	w        io.Writer
+	comments []*Comment
+}
+
+func NewCheckStyleCommentWriter(w io.Writer) *CheckStyleCommentWriter {
+	return &CheckStyleCommentWriter{w: w}
+}
+
+func (cw *CheckStyleCommentWriter) Post(_ context.Context, c *Comment) error {
+	cw.comments = append(cw.comments, c)
+	return nil
+}
+
+func (*CheckStyleCommentWriter) ShouldPrependGitRelDir() bool { return false }
+
+func (*CheckStyleCommentWriter) report() {}
+
+func (cw *CheckStyleCommentWriter) Flush(_ context.Context) error {
+	files := make(map[string]*parser.CheckStyleFile)
+	result := &parser.CheckStyleResult{Version: "4.3"}
+	for _, c := range cw.comments {
+		d := c.Result.Diagnostic
+		path := d.GetLocation().GetPath()
+		f, ok := files[path]
+		if !ok {
+			f = &parser.CheckStyleFile{Name: path}
+			files[path] = f
+			result.Files = append(result.Files, f)
+		}
+		toolName := d.GetSource().GetName()
+		if toolName == "" {
+			toolName = c.ToolName
+		}
+		source := toolName
+		if code := d.GetCode().GetValue(); code != "" {
+			source = code
+			if toolName != "" {
+				source = toolName + "." + code
+			}
+		}
+		start := d.GetLocation().GetRange().GetStart()
+		f.Errors = append(f.Errors, &parser.CheckStyleError{
+			Line:     int(start.GetLine()),
+			Column:   int(start.GetColumn()),
+			Message:  d.GetMessage(),
+			Severity: severity2checkstyle(d.GetSeverity()),
+			Source:   source,
+		})
+	}
+	sort.Slice(result.Files, func(i, j int) bool {
+		return result.Files[i].Name < result.Files[j].Name
+	})
+	for _, f := range result.Files {
+		sort.SliceStable(f.Errors, func(i, j int) bool {
+			if f.Errors[i].Line != f.Errors[j].Line {
+				return f.Errors[i].Line < f.Errors[j].Line
+			}
+			return f.Errors[i].Column < f.Errors[j].Column
+		})
+	}
+	cw.comments = nil
+	if _, err := io.WriteString(cw.w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(cw.w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(result); err != nil {
+		return err
+	}
+	_, err := io.WriteString(cw.w, "\n")
+	return err
+}
+
+func severity2checkstyle(s rdf.Severity) string {
+	switch s {
+	case rdf.Severity_ERROR:
+		return "error"
+	case rdf.Severity_WARNING:
+		return "warning"
+	case rdf.Severity_INFO:
+		return "info"
+	default:
+		// Checkstyle requires severity, so treat unknown severity as error.
+		return "error"
+	}
+}
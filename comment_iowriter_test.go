@@ -3,10 +3,14 @@ package reviewdog
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"regexp"
 	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
 	"github.com/reviewdog/reviewdog/filter"
 	"github.com/reviewdog/reviewdog/proto/rdf"
 )
@@ -303,9 +307,54 @@ func TestSARIFCommentWriter_Post(t *testing.T) {
 				},
 			},
 		},
+		{
+			Result: &filter.FilteredDiagnostic{
+				Diagnostic: &rdf.Diagnostic{
+					Location: &rdf.Location{
+						Path: "a.go",
+						Range: &rdf.Range{
+							Start: &rdf.Position{Line: 1, Column: 5},
+							End:   &rdf.Position{Line: 1, Column: 8},
+						},
+					},
+					Message:  "use baz",
+					Severity: rdf.Severity_INFO,
+					Code:     &rdf.Code{Value: "rule1", Url: "https://example.com/rule1"},
+					Suggestions: []*rdf.Suggestion{
+						{
+							Range: &rdf.Range{
+								Start: &rdf.Position{Line: 1, Column: 5},
+								End:   &rdf.Position{Line: 1, Column: 8},
+							},
+							Text: "baz",
+						},
+					},
+					RelatedLocations: []*rdf.RelatedLocation{
+						{
+							Message:  "defined here",
+							Location: &rdf.Location{Path: "b.go", Range: &rdf.Range{Start: &rdf.Position{Line: 2}}},
+						},
+					},
+					Fingerprints: map[string]string{"primaryLocationLineHash": "abc"},
+				},
+			},
+			ToolName: "other",
+		},
+		{
+			Result: &filter.FilteredDiagnostic{
+				Diagnostic: &rdf.Diagnostic{
+					Location: &rdf.Location{Path: "a.go", Range: &rdf.Range{Start: &rdf.Position{Line: 3}}},
+					Message:  "again",
+					Severity: rdf.Severity_ERROR,
+					Code:     &rdf.Code{Value: "rule1"},
+				},
+			},
+			ToolName: "other",
+		},
 	}
 	buf := new(bytes.Buffer)
 	cw := NewSARIFCommentWriter(buf, "tool name [constructor]")
+	cw.SetTool("clean", "")
 	for _, c := range comments {
 		if err := cw.Post(context.Background(), c); err != nil {
 			t.Error(err)
@@ -319,22 +368,194 @@ func TestSARIFCommentWriter_Post(t *testing.T) {
   "$schema": "https://docs.oasis-open.org/sarif/sarif/v2.1.0/errata01/os/schemas/sarif-schema-2.1.0.json",
   "runs": [
     {
+      "automationDetails": {
+        "id": "clean/"
+      },
+      "conversion": {
+        "tool": {
+          "driver": {
+            "informationUri": "https://github.com/reviewdog/reviewdog",
+            "name": "reviewdog",
+            "version": "master"
+          }
+        }
+      },
+      "results": [],
+      "tool": {
+        "driver": {
+          "name": "clean"
+        }
+      }
+    },
+    {
+      "automationDetails": {
+        "id": "other/"
+      },
+      "conversion": {
+        "tool": {
+          "driver": {
+            "informationUri": "https://github.com/reviewdog/reviewdog",
+            "name": "reviewdog",
+            "version": "master"
+          }
+        }
+      },
       "results": [
         {
+          "fixes": [
+            {
+              "artifactChanges": [
+                {
+                  "artifactLocation": {
+                    "uri": "a.go"
+                  },
+                  "replacements": [
+                    {
+                      "deletedRegion": {
+                        "endColumn": 8,
+                        "endLine": 1,
+                        "startColumn": 5,
+                        "startLine": 1
+                      },
+                      "insertedContent": {
+                        "text": "baz"
+                      }
+                    }
+                  ]
+                }
+              ]
+            }
+          ],
+          "level": "note",
           "locations": [
             {
               "physicalLocation": {
                 "artifactLocation": {
-                  "uri": "/path/to/file"
+                  "uri": "a.go"
+                },
+                "region": {
+                  "endColumn": 8,
+                  "endLine": 1,
+                  "startColumn": 5,
+                  "startLine": 1
+                }
+              }
+            }
+          ],
+          "message": {
+            "text": "use baz"
+          },
+          "partialFingerprints": {
+            "primaryLocationLineHash": "abc",
+            "reviewdog/v1": "cfd6a680bbcd2a6e"
+          },
+          "relatedLocations": [
+            {
+              "id": 0,
+              "message": {
+                "text": "defined here"
+              },
+              "physicalLocation": {
+                "artifactLocation": {
+                  "uri": "b.go"
+                },
+                "region": {
+                  "startLine": 2
+                }
+              }
+            }
+          ],
+          "ruleId": "rule1",
+          "ruleIndex": 0
+        },
+        {
+          "level": "error",
+          "locations": [
+            {
+              "physicalLocation": {
+                "artifactLocation": {
+                  "uri": "a.go"
                 },
-                "region": {}
+                "region": {
+                  "startLine": 3
+                }
+              }
+            }
+          ],
+          "message": {
+            "text": "again"
+          },
+          "partialFingerprints": {
+            "reviewdog/v1": "2c02c01a39d22c94"
+          },
+          "ruleId": "rule1",
+          "ruleIndex": 0
+        }
+      ],
+      "tool": {
+        "driver": {
+          "name": "other",
+          "rules": [
+            {
+              "helpUri": "https://example.com/rule1",
+              "id": "rule1"
+            }
+          ]
+        }
+      }
+    },
+    {
+      "automationDetails": {
+        "id": "tool name/"
+      },
+      "conversion": {
+        "tool": {
+          "driver": {
+            "informationUri": "https://github.com/reviewdog/reviewdog",
+            "name": "reviewdog",
+            "version": "master"
+          }
+        }
+      },
+      "results": [
+        {
+          "locations": [
+            {
+              "physicalLocation": {
+                "artifactLocation": {
+                  "uri": "/path/to/file"
+                }
               }
             }
           ],
           "message": {
             "text": "message"
+          },
+          "partialFingerprints": {
+            "reviewdog/v1": "31e29add5b21cb10"
           }
-        },
+        }
+      ],
+      "tool": {
+        "driver": {
+          "name": "tool name"
+        }
+      }
+    },
+    {
+      "automationDetails": {
+        "id": "tool name [constructor]/"
+      },
+      "conversion": {
+        "tool": {
+          "driver": {
+            "informationUri": "https://github.com/reviewdog/reviewdog",
+            "name": "reviewdog",
+            "version": "master"
+          }
+        }
+      },
+      "results": [
         {
           "locations": [
             {
@@ -350,6 +571,9 @@ func TestSARIFCommentWriter_Post(t *testing.T) {
           ],
           "message": {
             "text": "message"
+          },
+          "partialFingerprints": {
+            "reviewdog/v1": "2f9c9c6e617169e6"
           }
         },
         {
@@ -367,11 +591,15 @@ func TestSARIFCommentWriter_Post(t *testing.T) {
           ],
           "message": {
             "text": "message"
+          },
+          "partialFingerprints": {
+            "reviewdog/v1": "aaee252f6d1f5aa0"
           }
         }
       ],
       "tool": {
         "driver": {
+          "informationUri": "tool url",
           "name": "tool name [constructor]"
         }
       }
@@ -384,3 +612,247 @@ func TestSARIFCommentWriter_Post(t *testing.T) {
 		t.Errorf("got\n%v\nwant:\n%v", got, want)
 	}
 }
+
+func TestCodeQualityCommentWriter_Post(t *testing.T) {
+	newComments := func(lineOffset int32) []*Comment {
+		return []*Comment{
+			{
+				Result: &filter.FilteredDiagnostic{
+					Diagnostic: &rdf.Diagnostic{
+						Location: &rdf.Location{
+							Path: "a.go",
+							Range: &rdf.Range{
+								Start: &rdf.Position{Line: 1 + lineOffset, Column: 2},
+								End:   &rdf.Position{Line: 3 + lineOffset},
+							},
+						},
+						Message:  "message",
+						Severity: rdf.Severity_ERROR,
+						Code:     &rdf.Code{Value: "rule1"},
+					},
+				},
+				ToolName: "tool",
+			},
+			{
+				// Same issue as the first one in another line.
+				Result: &filter.FilteredDiagnostic{
+					Diagnostic: &rdf.Diagnostic{
+						Location: &rdf.Location{
+							Path:  "a.go",
+							Range: &rdf.Range{Start: &rdf.Position{Line: 10 + lineOffset}},
+						},
+						Message:  "message",
+						Severity: rdf.Severity_ERROR,
+						Code:     &rdf.Code{Value: "rule1"},
+					},
+				},
+				ToolName: "tool",
+			},
+			{
+				Result: &filter.FilteredDiagnostic{
+					Diagnostic: &rdf.Diagnostic{
+						Location: &rdf.Location{Path: "b.go"},
+						Message:  "file level",
+						Source:   &rdf.Source{Name: "other tool"},
+					},
+				},
+				ToolName: "tool",
+			},
+		}
+	}
+	write := func(comments []*Comment) []*CodeQualityIssue {
+		t.Helper()
+		buf := new(bytes.Buffer)
+		cw := NewCodeQualityCommentWriter(buf)
+		for _, c := range comments {
+			if err := cw.Post(context.Background(), c); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := cw.Flush(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		var issues []*CodeQualityIssue
+		if err := json.Unmarshal(buf.Bytes(), &issues); err != nil {
+			t.Fatal(err)
+		}
+		return issues
+	}
+
+	got := write(newComments(0))
+	want := []*CodeQualityIssue{
+		{
+			Description: "message",
+			CheckName:   "rule1",
+			Severity:    "critical",
+			EngineName:  "tool",
+			Location:    CodeQualityLocation{Path: "a.go", Lines: CodeQualityLines{Begin: 1, End: 3}},
+		},
+		{
+			Description: "message",
+			CheckName:   "rule1",
+			Severity:    "critical",
+			EngineName:  "tool",
+			Location:    CodeQualityLocation{Path: "a.go", Lines: CodeQualityLines{Begin: 10, End: 10}},
+		},
+		{
+			Description: "file level",
+			CheckName:   "other tool",
+			Severity:    "info",
+			EngineName:  "other tool",
+			Location:    CodeQualityLocation{Path: "b.go", Lines: CodeQualityLines{Begin: 1, End: 1}},
+		},
+	}
+	if diff := cmp.Diff(got, want, cmpopts.IgnoreFields(CodeQualityIssue{}, "Fingerprint")); diff != "" {
+		t.Errorf("diff (-got +want):\n%s", diff)
+	}
+	if got[0].Fingerprint == got[1].Fingerprint {
+		t.Errorf("fingerprints of different issues must be different: %s", got[0].Fingerprint)
+	}
+	// Fingerprints should be stable even if lines change.
+	shifted := write(newComments(5))
+	for i := range got {
+		if got[i].Fingerprint != shifted[i].Fingerprint {
+			t.Errorf("fingerprint[%d] changed: %s != %s", i, got[i].Fingerprint, shifted[i].Fingerprint)
+		}
+	}
+}
+
+func TestJUnitCommentWriter_Post(t *testing.T) {
+	comments := []*Comment{
+		{
+			Result: &filter.FilteredDiagnostic{
+				Diagnostic: &rdf.Diagnostic{
+					Location: &rdf.Location{
+						Path:  "a.go",
+						Range: &rdf.Range{Start: &rdf.Position{Line: 12, Column: 3}},
+					},
+					Message:  "message <1>",
+					Severity: rdf.Severity_ERROR,
+					Code:     &rdf.Code{Value: "rule1", Url: "https://example.com/rule1"},
+				},
+			},
+			ToolName: "golint",
+		},
+		{
+			Result: &filter.FilteredDiagnostic{
+				Diagnostic: &rdf.Diagnostic{
+					Location: &rdf.Location{Path: "b.go"},
+					Message:  "message 2",
+				},
+			},
+			ToolName: "golint",
+		},
+		{
+			Result: &filter.FilteredDiagnostic{
+				Diagnostic: &rdf.Diagnostic{
+					Location: &rdf.Location{Path: "c.js"},
+					Message:  "message 3",
+					Source:   &rdf.Source{Name: "eslint"},
+					Code:     &rdf.Code{Value: "semi"},
+				},
+			},
+			ToolName: "lint",
+		},
+	}
+	buf := new(bytes.Buffer)
+	cw := NewJUnitCommentWriter(buf, "golint")
+	cw.SetTool("govet", "")
+	for _, c := range comments {
+		if err := cw.Post(context.Background(), c); err != nil {
+			t.Error(err)
+		}
+	}
+	if err := cw.Flush(context.Background()); err != nil {
+		t.Error(err)
+	}
+	want := `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="reviewdog" tests="4" failures="3">
+  <testsuite name="golint" tests="2" failures="2">
+    <testcase classname="a.go" name="rule1:12">
+      <failure message="message &lt;1&gt;" type="ERROR">a.go:12:3: message &lt;1&gt;&#xA;https://example.com/rule1</failure>
+    </testcase>
+    <testcase classname="b.go" name="golint">
+      <failure message="message 2" type="UNKNOWN_SEVERITY">b.go: message 2</failure>
+    </testcase>
+  </testsuite>
+  <testsuite name="govet" tests="1" failures="0">
+    <testcase classname="govet" name="govet"></testcase>
+  </testsuite>
+  <testsuite name="lint" tests="1" failures="1">
+    <testcase classname="c.js" name="eslint.semi">
+      <failure message="message 3" type="UNKNOWN_SEVERITY">c.js: message 3</failure>
+    </testcase>
+  </testsuite>
+</testsuites>
+`
+	if diff := cmp.Diff(buf.String(), want); diff != "" {
+		t.Errorf("diff (-got +want):\n%s", diff)
+	}
+}
+
+func TestCheckStyleCommentWriter_Post(t *testing.T) {
+	comments := []*Comment{
+		{
+			Result: &filter.FilteredDiagnostic{
+				Diagnostic: &rdf.Diagnostic{
+					Location: &rdf.Location{
+						Path:  "b.go",
+						Range: &rdf.Range{Start: &rdf.Position{Line: 3, Column: 1}},
+					},
+					Message:  "b3",
+					Severity: rdf.Severity_WARNING,
+					Code:     &rdf.Code{Value: "rule1"},
+				},
+			},
+			ToolName: "golint",
+		},
+		{
+			Result: &filter.FilteredDiagnostic{
+				Diagnostic: &rdf.Diagnostic{
+					Location: &rdf.Location{
+						Path:  "b.go",
+						Range: &rdf.Range{Start: &rdf.Position{Line: 1, Column: 5}},
+					},
+					Message: "b1 <msg>",
+					Source:  &rdf.Source{Name: "eslint"},
+				},
+			},
+			ToolName: "golint",
+		},
+		{
+			Result: &filter.FilteredDiagnostic{
+				Diagnostic: &rdf.Diagnostic{
+					Location: &rdf.Location{Path: "a.go"},
+					Message:  "file level",
+					Severity: rdf.Severity_INFO,
+				},
+			},
+			ToolName: "govet",
+		},
+	}
+	buf := new(bytes.Buffer)
+	cw := NewCheckStyleCommentWriter(buf)
+	for _, c := range comments {
+		if err := cw.Post(context.Background(), c); err != nil {
+			t.Error(err)
+		}
+	}
+	if err := cw.Flush(context.Background()); err != nil {
+		t.Error(err)
+	}
+	want := `<?xml version="1.0" encoding="UTF-8"?>
+<checkstyle version="4.3">
+  <file name="a.go">
+    <error message="file level" severity="info" source="govet"></error>
+  </file>
+  <file name="b.go">
+    <error column="5" line="1" message="b1 &lt;msg&gt;" severity="error" source="eslint"></error>
+    <error column="1" line="3" message="b3" severity="warning" source="golint.rule1"></error>
+  </file>
+</checkstyle>
+`
+	if diff := cmp.Diff(buf.String(), want); diff != "" {
+		t.Errorf("diff (-got +want):\n%s", diff)
+	}
+}
@@ -0,0 +1,232 @@
+package reviewdog
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/reviewdog/reviewdog/proto/rdf"
+)
+
+var _ CommentService = &PrettyCommentWriter{}
+
+// PrettyCommentWriter is comment writer which writes human-friendly results
+// with code frames, related locations, rule links and suggestion diffs for
+// local use.
+//
+// Format:
+//
+//	<severity>: <message> [<tool name>]
+//	  --> <file>:<lnum>:<col>
+//	   |
+//	 3 | x := foo(bar)
+//	   |      ^^^^^^^^
+//	   = rule: <code> (<url>)
+//	   = related: <file>:<lnum>:<col>: <message>
+//	   = suggestion:
+//	 3 - x := foo(bar)
+//	   + x := foo(baz)
+type PrettyCommentWriter struct {
+	w     io.Writer
+	color bool
+	srcs  map[string][]string
+	// readFile reads source file content. Replaceable for testing.
+	readFile func(path string) ([]byte, error)
+}
+
+// NewPrettyCommentWriter returns a new PrettyCommentWriter. It colors output
+// with ANSI escape sequences if color is true.
+func NewPrettyCommentWriter(w io.Writer, color bool) *PrettyCommentWriter {
+	return &PrettyCommentWriter{
+		w:        w,
+		color:    color,
+		srcs:     make(map[string][]string),
+		readFile: os.ReadFile,
+	}
+}
+
+const (
+	ansiReset  = "\x1b[0m"
+	ansiBold   = "\x1b[1m"
+	ansiRed    = "\x1b[31m"
+	ansiGreen  = "\x1b[32m"
+	ansiYellow = "\x1b[33m"
+	ansiBlue   = "\x1b[34m"
+	ansiCyan   = "\x1b[36m"
+)
+
+func (pw *PrettyCommentWriter) paint(s string, codes ...string) string {
+	if !pw.color || s == "" {
+		return s
+	}
+	return strings.Join(codes, "") + s + ansiReset
+}
+
+func (pw *PrettyCommentWriter) sourceLines(path string) []string {
+	if lines, ok := pw.srcs[path]; ok {
+		return lines
+	}
+	var lines []string
+	if b, err := pw.readFile(path); err == nil {
+		lines = strings.Split(string(b), "\n")
+	}
+	pw.srcs[path] = lines
+	return lines
+}
+
+func (pw *PrettyCommentWriter) Post(_ context.Context, c *Comment) error {
+	d := c.Result.Diagnostic
+	toolName := d.GetSource().GetName()
+	if toolName == "" {
+		toolName = c.ToolName
+	}
+	loc := d.GetLocation()
+	lines := pw.sourceLines(loc.GetPath())
+
+	var frame []*codeLine
+	if start := int(loc.GetRange().GetStart().GetLine()); start > 0 && start <= len(lines) {
+		end := min(max(int(loc.GetRange().GetEnd().GetLine()), start), len(lines))
+		for l := start; l <= end; l++ {
+			frame = append(frame, &codeLine{Num: l, Text: lines[l-1]})
+		}
+	}
+	var previews [][]*codeLine
+	for _, s := range d.GetSuggestions() {
+		if preview := suggestionPreview(lines, s); preview != nil {
+			previews = append(previews, preview)
+		}
+	}
+	// Width of line numbers in the gutter.
+	width := 1
+	for _, ls := range append([][]*codeLine{frame}, previews...) {
+		for _, l := range ls {
+			width = max(width, len(strconv.Itoa(l.Num)))
+		}
+	}
+	gutter := func(num int, mark string) string {
+		n := ""
+		if num > 0 {
+			n = strconv.Itoa(num)
+		}
+		return pw.paint(fmt.Sprintf("%*s %s", width, n, mark), ansiBold, ansiBlue)
+	}
+
+	var sb strings.Builder
+	sev := severityName(d.GetSeverity())
+	if d.GetSeverity() == rdf.Severity_UNKNOWN_SEVERITY {
+		sb.WriteString(pw.paint(d.GetMessage(), ansiBold))
+	} else {
+		sb.WriteString(pw.paint(sev, ansiBold, pw.severityColor(d.GetSeverity())))
+		sb.WriteString(pw.paint(": "+d.GetMessage(), ansiBold))
+	}
+	if toolName != "" {
+		fmt.Fprintf(&sb, " [%s]", toolName)
+	}
+	sb.WriteString("\n")
+	fmt.Fprintf(&sb, "%*s%s %s\n", width, "", pw.paint("-->", ansiBold, ansiBlue), locationString(loc))
+
+	if len(frame) > 0 {
+		sb.WriteString(gutter(0, "|") + "\n")
+		rng := loc.GetRange()
+		singleLine := len(frame) == 1 && rng.GetStart().GetColumn() > 0
+		for _, l := range frame {
+			mark := "|"
+			if len(frame) > 1 {
+				mark = "|" + pw.paint(">", ansiBold, pw.severityColor(d.GetSeverity()))
+			}
+			fmt.Fprintf(&sb, "%s %s\n", gutter(l.Num, mark), l.Text)
+		}
+		if singleLine {
+			fmt.Fprintf(&sb, "%s %s\n", gutter(0, "|"),
+				pw.paint(caret(frame[0].Text, rng), ansiBold, pw.severityColor(d.GetSeverity())))
+		}
+	}
+
+	note := func(label, text string) {
+		fmt.Fprintf(&sb, "%s %s%s\n", gutter(0, "="), pw.paint(label+":", ansiBold), text)
+	}
+	if code := d.GetCode().GetValue(); code != "" {
+		text := " " + code
+		if url := d.GetCode().GetUrl(); url != "" {
+			text += " (" + pw.paint(url, ansiCyan) + ")"
+		}
+		note("rule", text)
+	}
+	for _, rl := range d.GetRelatedLocations() {
+		text := " " + locationString(rl.GetLocation())
+		if msg := rl.GetMessage(); msg != "" {
+			text += ": " + msg
+		}
+		note("related", text)
+	}
+	for _, preview := range previews {
+		note("suggestion", "")
+		for _, l := range preview {
+			switch l.Type {
+			case "add":
+				fmt.Fprintf(&sb, "%s %s\n", gutter(0, pw.paint("+", ansiGreen)), pw.paint(l.Text, ansiGreen))
+			case "del":
+				fmt.Fprintf(&sb, "%s %s\n", gutter(l.Num, pw.paint("-", ansiRed)), pw.paint(l.Text, ansiRed))
+			default:
+				fmt.Fprintf(&sb, "%s %s\n", gutter(l.Num, " "), l.Text)
+			}
+		}
+	}
+	sb.WriteString("\n")
+	_, err := io.WriteString(pw.w, sb.String())
+	return err
+}
+
+func (*PrettyCommentWriter) ShouldPrependGitRelDir() bool { return false }
+
+func (*PrettyCommentWriter) severityColor(s rdf.Severity) string {
+	switch s {
+	case rdf.Severity_ERROR:
+		return ansiRed
+	case rdf.Severity_WARNING:
+		return ansiYellow
+	default:
+		return ansiBlue
+	}
+}
+
+// locationString returns <file>[:<lnum>[:<col>]] of loc.
+func locationString(loc *rdf.Location) string {
+	s := loc.GetPath()
+	start := loc.GetRange().GetStart()
+	if start.GetLine() > 0 {
+		s += fmt.Sprintf(":%d", start.GetLine())
+		if start.GetColumn() > 0 {
+			s += fmt.Sprintf(":%d", start.GetColumn())
+		}
+	}
+	return s
+}
+
+// caret returns a line which underlines the range in line with carets. Tabs
+// before the range are kept so that carets are aligned with the line.
+func caret(line string, rng *rdf.Range) string {
+	sc := min(max(int(rng.GetStart().GetColumn())-1, 0), len(line))
+	ec := sc + 1
+	if rng.GetEnd().GetLine() == rng.GetStart().GetLine() && rng.GetEnd().GetColumn() > 0 {
+		ec = min(max(int(rng.GetEnd().GetColumn())-1, sc+1), len(line))
+	}
+	var sb strings.Builder
+	for _, r := range line[:sc] {
+		if r == '\t' {
+			sb.WriteRune('\t')
+		} else {
+			sb.WriteRune(' ')
+		}
+	}
+	n := 1
+	if ec <= len(line) && sc < ec {
+		n = max(utf8.RuneCountInString(line[sc:ec]), 1)
+	}
+	sb.WriteString(strings.Repeat("^", n))
+	return sb.String()
+}
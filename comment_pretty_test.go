@@ -0,0 +1,133 @@
+package reviewdog
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/reviewdog/reviewdog/filter"
+	"github.com/reviewdog/reviewdog/proto/rdf"
+)
+
+func TestPrettyCommentWriter_Post(t *testing.T) {
+	src := "package main\n\nfunc main() {\n\tx := foo(bar)\n}\n"
+	comments := []*Comment{
+		{
+			Result: &filter.FilteredDiagnostic{
+				Diagnostic: &rdf.Diagnostic{
+					Location: &rdf.Location{
+						Path: "main.go",
+						Range: &rdf.Range{
+							Start: &rdf.Position{Line: 4, Column: 7},
+							End:   &rdf.Position{Line: 4, Column: 15},
+						},
+					},
+					Message:  "use baz",
+					Severity: rdf.Severity_ERROR,
+					Code:     &rdf.Code{Value: "SA1000", Url: "https://example.com/SA1000"},
+					RelatedLocations: []*rdf.RelatedLocation{
+						{
+							Message: "bar is defined here",
+							Location: &rdf.Location{
+								Path:  "bar.go",
+								Range: &rdf.Range{Start: &rdf.Position{Line: 1, Column: 5}},
+							},
+						},
+					},
+					Suggestions: []*rdf.Suggestion{
+						{
+							Range: &rdf.Range{
+								Start: &rdf.Position{Line: 4, Column: 11},
+								End:   &rdf.Position{Line: 4, Column: 14},
+							},
+							Text: "baz",
+						},
+					},
+				},
+			},
+			ToolName: "staticcheck",
+		},
+		{
+			Result: &filter.FilteredDiagnostic{
+				Diagnostic: &rdf.Diagnostic{
+					Location: &rdf.Location{
+						Path:  "main.go",
+						Range: &rdf.Range{Start: &rdf.Position{Line: 3}, End: &rdf.Position{Line: 5}},
+					},
+					Message:  "multiline",
+					Severity: rdf.Severity_WARNING,
+					Source:   &rdf.Source{Name: "golint"},
+				},
+			},
+			ToolName: "staticcheck",
+		},
+		{
+			Result: &filter.FilteredDiagnostic{
+				Diagnostic: &rdf.Diagnostic{
+					Location: &rdf.Location{Path: "missing.go"},
+					Message:  "file level",
+				},
+			},
+			ToolName: "tool",
+		},
+	}
+	buf := new(bytes.Buffer)
+	pw := NewPrettyCommentWriter(buf, false)
+	pw.readFile = func(path string) ([]byte, error) {
+		if path == "main.go" {
+			return []byte(src), nil
+		}
+		return nil, errors.New("not found")
+	}
+	for _, c := range comments {
+		if err := pw.Post(context.Background(), c); err != nil {
+			t.Error(err)
+		}
+	}
+	want := `error: use baz [staticcheck]
+ --> main.go:4:7
+  |
+4 | 	x := foo(bar)
+  | 	     ^^^^^^^^
+  = rule: SA1000 (https://example.com/SA1000)
+  = related: bar.go:1:5: bar is defined here
+  = suggestion:
+4 - 	x := foo(bar)
+  + 	x := foo(baz)
+
+warning: multiline [golint]
+ --> main.go:3
+  |
+3 |> func main() {
+4 |> 	x := foo(bar)
+5 |> }
+
+file level [tool]
+ --> missing.go
+
+`
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("result has diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestPrettyCommentWriter_color(t *testing.T) {
+	buf := new(bytes.Buffer)
+	pw := NewPrettyCommentWriter(buf, true)
+	pw.readFile = func(string) ([]byte, error) { return []byte("a\n"), nil }
+	c := &Comment{Result: &filter.FilteredDiagnostic{Diagnostic: &rdf.Diagnostic{
+		Location: &rdf.Location{Path: "a.txt", Range: &rdf.Range{Start: &rdf.Position{Line: 1, Column: 1}}},
+		Message:  "msg",
+		Severity: rdf.Severity_ERROR,
+	}}}
+	if err := pw.Post(context.Background(), c); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), ansiRed) {
+		t.Errorf("result is not colored: %q", buf.String())
+	}
+}
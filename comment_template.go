@@ -0,0 +1,151 @@
+package reviewdog
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/reviewdog/reviewdog/service/serviceutil"
+)
+
+var _ ReportCommentService = &TemplateCommentWriter{}
+
+// TemplateCommentWriter renders results with user-defined Go text/template.
+//
+// If the template defines a template named "comment" and the body of the
+// template is empty, "comment" is rendered for each *Comment. Otherwise, the
+// template is rendered once with *TemplateReport, which has all the results.
+type TemplateCommentWriter struct {
+	w        io.Writer
+	tmpl     *template.Template
+	comments []*Comment
+	// readFile reads source file content. Replaceable for testing.
+	readFile func(path string) ([]byte, error)
+	// gitRelWorkdir returns the current directory relative to the git
+	// repository root. Replaceable for testing.
+	gitRelWorkdir func() (string, error)
+}
+
+// TemplateReport is the data of the whole-run template.
+type TemplateReport struct {
+	Comments []*Comment
+}
+
+// NewTemplateCommentWriter returns a new TemplateCommentWriter which renders
+// results with the template text.
+func NewTemplateCommentWriter(w io.Writer, text string) (*TemplateCommentWriter, error) {
+	cw := &TemplateCommentWriter{
+		w:             w,
+		readFile:      os.ReadFile,
+		gitRelWorkdir: serviceutil.GitRelWorkdir,
+	}
+	tmpl, err := template.New("reviewdog").Funcs(cw.funcs()).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("fail to parse template: %w", err)
+	}
+	cw.tmpl = tmpl
+	return cw, nil
+}
+
+func (cw *TemplateCommentWriter) Post(_ context.Context, c *Comment) error {
+	cw.comments = append(cw.comments, c)
+	return nil
+}
+
+func (*TemplateCommentWriter) ShouldPrependGitRelDir() bool { return false }
+
+func (*TemplateCommentWriter) report() {}
+
+func (cw *TemplateCommentWriter) Flush(_ context.Context) error {
+	defer func() { cw.comments = nil }()
+	if c := cw.tmpl.Lookup("comment"); c != nil && (cw.tmpl.Tree == nil || parse.IsEmptyTree(cw.tmpl.Tree.Root)) {
+		for _, comment := range cw.comments {
+			if err := c.Execute(cw.w, comment); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return cw.tmpl.Execute(cw.w, &TemplateReport{Comments: cw.comments})
+}
+
+func (cw *TemplateCommentWriter) funcs() template.FuncMap {
+	var relDir *string
+	return template.FuncMap{
+		// severity returns lower case name of severity (error, warning, info or
+		// unknown).
+		"severity": severityName,
+		// toolName returns the source name of the result or the tool name.
+		"toolName": func(c *Comment) string {
+			if name := c.Result.Diagnostic.GetSource().GetName(); name != "" {
+				return name
+			}
+			return c.ToolName
+		},
+		// relpath returns the path relative to the git repository root.
+		"relpath": func(p string) string {
+			if relDir == nil {
+				dir, _ := cw.gitRelWorkdir()
+				relDir = &dir
+			}
+			if path.IsAbs(p) || *relDir == "" {
+				return p
+			}
+			return path.Join(*relDir, p)
+		},
+		"snippet":     cw.snippet,
+		"fingerprint": func(c *Comment) (string, error) { return serviceutil.Fingerprint(c.Result.Diagnostic) },
+		"markdown":    escapeMarkdown,
+	}
+}
+
+// snippet returns source lines of the range of the result.
+func (cw *TemplateCommentWriter) snippet(c *Comment) string {
+	rng := c.Result.Diagnostic.GetLocation().GetRange()
+	start := int(rng.GetStart().GetLine())
+	if start <= 0 {
+		return ""
+	}
+	end := max(int(rng.GetEnd().GetLine()), start)
+	if src := c.Result.SourceLines; len(src) > 0 {
+		var lines []string
+		for l := start; l <= end; l++ {
+			line, ok := src[l]
+			if !ok {
+				lines = nil
+				break
+			}
+			lines = append(lines, line)
+		}
+		if lines != nil {
+			return strings.Join(lines, "\n")
+		}
+	}
+	b, err := cw.readFile(c.Result.Diagnostic.GetLocation().GetPath())
+	if err != nil {
+		return ""
+	}
+	lines := strings.Split(string(b), "\n")
+	if start > len(lines) {
+		return ""
+	}
+	return strings.Join(lines[start-1:min(end, len(lines))], "\n")
+}
+
+var markdownEscaper = strings.NewReplacer(func() []string {
+	var oldnew []string
+	for _, c := range "\\`*_{}[]<>()#+-!|~" {
+		oldnew = append(oldnew, string(c), "\\"+string(c))
+	}
+	return oldnew
+}()...)
+
+// escapeMarkdown escapes Markdown special characters in s.
+func escapeMarkdown(s string) string {
+	return markdownEscaper.Replace(s)
+}
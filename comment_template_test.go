@@ -0,0 +1,131 @@
+package reviewdog
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/reviewdog/reviewdog/filter"
+	"github.com/reviewdog/reviewdog/proto/rdf"
+)
+
+func TestTemplateCommentWriter_Flush(t *testing.T) {
+	comments := []*Comment{
+		{
+			Result: &filter.FilteredDiagnostic{
+				Diagnostic: &rdf.Diagnostic{
+					Location: &rdf.Location{
+						Path:  "a.go",
+						Range: &rdf.Range{Start: &rdf.Position{Line: 2, Column: 1}},
+					},
+					Message:  "use *foo* [bar]",
+					Severity: rdf.Severity_ERROR,
+				},
+				SourceLines: map[int]string{2: "line2 from diff"},
+			},
+			ToolName: "golint",
+		},
+		{
+			Result: &filter.FilteredDiagnostic{
+				Diagnostic: &rdf.Diagnostic{
+					Location: &rdf.Location{
+						Path:  "a.go",
+						Range: &rdf.Range{Start: &rdf.Position{Line: 1}, End: &rdf.Position{Line: 2}},
+					},
+					Message: "multiline",
+					Source:  &rdf.Source{Name: "eslint"},
+				},
+			},
+			ToolName: "golint",
+		},
+	}
+	tests := []struct {
+		name string
+		tmpl string
+		want string
+	}{
+		{
+			name: "whole run",
+			tmpl: `{{len .Comments}} results
+{{range .Comments}}- {{severity .Result.Diagnostic.Severity}} [{{toolName .}}] {{relpath .Result.Diagnostic.Location.Path}}: {{markdown .Result.Diagnostic.Message}}
+{{end}}`,
+			want: `2 results
+- error [golint] sub/a.go: use \*foo\* \[bar\]
+- unknown [eslint] sub/a.go: multiline
+`,
+		},
+		{
+			name: "per comment",
+			tmpl: `{{define "comment"}}{{.Result.Diagnostic.Message}}:
+{{snippet .}}
+{{end}}`,
+			want: `use *foo* [bar]:
+line2 from diff
+multiline:
+line1
+line2
+`,
+		},
+		{
+			name: "whole run with comment template",
+			tmpl: `{{define "comment"}}* {{.Result.Diagnostic.Message}}
+{{end}}Results:
+{{range .Comments}}{{template "comment" .}}{{end}}`,
+			want: `Results:
+* use *foo* [bar]
+* multiline
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			cw, err := NewTemplateCommentWriter(buf, tt.tmpl)
+			if err != nil {
+				t.Fatal(err)
+			}
+			cw.readFile = func(string) ([]byte, error) { return []byte("line1\nline2\nline3\n"), nil }
+			cw.gitRelWorkdir = func() (string, error) { return "sub", nil }
+			for _, c := range comments {
+				if err := cw.Post(context.Background(), c); err != nil {
+					t.Error(err)
+				}
+			}
+			if err := cw.Flush(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, buf.String()); diff != "" {
+				t.Errorf("result has diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTemplateCommentWriter_fingerprint(t *testing.T) {
+	buf := new(bytes.Buffer)
+	cw, err := NewTemplateCommentWriter(buf, `{{range .Comments}}{{fingerprint .}}{{end}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &Comment{Result: &filter.FilteredDiagnostic{Diagnostic: &rdf.Diagnostic{
+		Message:      "msg",
+		Fingerprints: map[string]string{"id": "1"},
+	}}}
+	if err := cw.Post(context.Background(), c); err != nil {
+		t.Error(err)
+	}
+	if err := cw.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() == 0 {
+		t.Error("fingerprint is empty")
+	}
+}
+
+func TestNewTemplateCommentWriter_invalid(t *testing.T) {
+	if _, err := NewTemplateCommentWriter(new(bytes.Buffer), `{{.Comments`); err == nil {
+		t.Error("got nil error for invalid template")
+	}
+}
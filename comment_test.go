@@ -56,3 +56,57 @@ func TestMultiCommentService_Flush(t *testing.T) {
 		t.Error("MultiCommentService_Flush should run Flush() for every services")
 	}
 }
+
+type fakeNamedBulkCommentService struct {
+	fakeBulkCommentService
+	toolName string
+}
+
+func (f *fakeNamedBulkCommentService) SetTool(toolName string, _ string) {
+	f.toolName = toolName
+}
+
+func TestNewReportCommentService(t *testing.T) {
+	f := &fakeNamedBulkCommentService{}
+	w := NewReportCommentService(f)
+	w.(NamedCommentService).SetTool("golint", "")
+	if f.toolName != "golint" {
+		t.Errorf("SetTool should be forwarded: got %q", f.toolName)
+	}
+	if err := w.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if !f.calledFlush {
+		t.Error("Flush should be forwarded")
+	}
+	if w.(OldLineCommentService).SupportsOldLines() {
+		t.Error("SupportsOldLines should be false if the service doesn't support old lines")
+	}
+	if err := w.(FilteredCommentService).PostFiltered(context.Background(), &Comment{}); err != nil {
+		t.Errorf("PostFiltered should drop comments if the service doesn't support them: %v", err)
+	}
+
+	ff := &fakeFilteredBulkCommentService{}
+	fw := NewReportCommentService(ff)
+	if !fw.(OldLineCommentService).SupportsOldLines() {
+		t.Error("SupportsOldLines should be forwarded")
+	}
+	if err := fw.(FilteredCommentService).PostFiltered(context.Background(), &Comment{}); err != nil {
+		t.Fatal(err)
+	}
+	if ff.filtered != 1 {
+		t.Errorf("PostFiltered should be forwarded: got %d filtered comments", ff.filtered)
+	}
+}
+
+type fakeFilteredBulkCommentService struct {
+	fakeBulkCommentService
+	filtered int
+}
+
+func (f *fakeFilteredBulkCommentService) PostFiltered(_ context.Context, _ *Comment) error {
+	f.filtered++
+	return nil
+}
+
+func (*fakeFilteredBulkCommentService) SupportsOldLines() bool { return true }
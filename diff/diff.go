@@ -1,4 +1,4 @@
-// Package diff provides a utility to parse unified diff.
+// Package diff provides a utility to parse and generate unified diff.
 // https://en.wikipedia.org/wiki/Diff_utility#Unified_format
 package diff
 
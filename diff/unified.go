@@ -0,0 +1,237 @@
+package diff
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// contextLines is the number of unchanged lines around changes in hunks.
+const contextLines = 3
+
+type edit struct {
+	typ LineType
+	// index of the line in old for LineUnchanged and LineDeleted, or the number
+	// of preceding old lines for LineAdded.
+	old int
+	// index of the line in new for LineUnchanged and LineAdded, or the number
+	// of preceding new lines for LineDeleted.
+	new int
+}
+
+// Unified returns unified diff from old to new with 3 lines of context. It
+// returns nil if old and new are the same.
+func Unified(oldName, newName string, old, new []byte) []byte {
+	if bytes.Equal(old, new) {
+		return nil
+	}
+	a, b := splitLines(old), splitLines(new)
+	edits := lineEdits(a, b)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "--- %s\n", oldName)
+	fmt.Fprintf(&buf, "+++ %s\n", newName)
+	for i := 0; i < len(edits); {
+		if edits[i].typ == LineUnchanged {
+			i++
+			continue
+		}
+		start := max(i-contextLines, 0)
+		end := i
+		for {
+			for end < len(edits) && edits[end].typ != LineUnchanged {
+				end++
+			}
+			next := end
+			for next < len(edits) && edits[next].typ == LineUnchanged {
+				next++
+			}
+			// Merge changes if unchanged lines between them overlap contexts.
+			if next < len(edits) && next-end <= 2*contextLines {
+				end = next
+				continue
+			}
+			break
+		}
+		end = min(end+contextLines, len(edits))
+		writeHunk(&buf, a, b, edits[start:end])
+		i = end
+	}
+	return buf.Bytes()
+}
+
+func writeHunk(buf *bytes.Buffer, a, b []string, edits []edit) {
+	lold, lnew := 0, 0
+	for _, e := range edits {
+		if e.typ != LineAdded {
+			lold++
+		}
+		if e.typ != LineDeleted {
+			lnew++
+		}
+	}
+	sold, snew := edits[0].old, edits[0].new
+	if lold > 0 {
+		sold++
+	}
+	if lnew > 0 {
+		snew++
+	}
+	fmt.Fprintf(buf, "@@ -%d,%d +%d,%d @@\n", sold, lold, snew, lnew)
+	for _, e := range edits {
+		var prefix byte
+		var line string
+		switch e.typ {
+		case LineUnchanged:
+			prefix, line = ' ', a[e.old]
+		case LineDeleted:
+			prefix, line = '-', a[e.old]
+		case LineAdded:
+			prefix, line = '+', b[e.new]
+		}
+		buf.WriteByte(prefix)
+		buf.WriteString(line)
+		if len(line) == 0 || line[len(line)-1] != '\n' {
+			buf.WriteString("\n\\ No newline at end of file\n")
+		}
+	}
+}
+
+// splitLines splits s into lines including line breaks.
+func splitLines(s []byte) []string {
+	var lines []string
+	for len(s) > 0 {
+		i := bytes.IndexByte(s, '\n') + 1
+		if i == 0 {
+			i = len(s)
+		}
+		lines = append(lines, string(s[:i]))
+		s = s[i:]
+	}
+	return lines
+}
+
+// maxSnakeCost is the maximum number of differences to search for the middle
+// snake from each end. Lines which need more differences are replaced as a
+// whole to bound the time for files which are mostly rewritten.
+const maxSnakeCost = 1024
+
+// lineEdits returns the shortest edit script from a to b with the linear
+// space refinement of Myers' diff algorithm, which finds the middle snake of
+// the edit graph and divides the problem recursively. It uses O(len(a)+len(b))
+// memory regardless of the number of differences. The script may not be the
+// shortest if a part of it has more than 2*maxSnakeCost differences.
+func lineEdits(a, b []string) []edit {
+	l := &lineDiffer{a: a, b: b, edits: make([]edit, 0, len(a)+len(b))}
+	l.compare(0, len(a), 0, len(b))
+	return l.edits
+}
+
+type lineDiffer struct {
+	a, b  []string
+	edits []edit
+}
+
+// compare appends edits from a[alo:ahi] to b[blo:bhi].
+func (l *lineDiffer) compare(alo, ahi, blo, bhi int) {
+	a, b := l.a, l.b
+	// Trim common prefix and suffix to reduce the cost.
+	for alo < ahi && blo < bhi && a[alo] == b[blo] {
+		l.edits = append(l.edits, edit{typ: LineUnchanged, old: alo, new: blo})
+		alo++
+		blo++
+	}
+	suffix := 0
+	for alo < ahi-suffix && blo < bhi-suffix && a[ahi-1-suffix] == b[bhi-1-suffix] {
+		suffix++
+	}
+	ahi -= suffix
+	bhi -= suffix
+
+	switch {
+	case alo == ahi || blo == bhi:
+		l.replace(alo, ahi, blo, bhi)
+	default:
+		// Both halves have less differences than the whole since the first
+		// and the last lines differ.
+		x, y, u, v, ok := middleSnake(a[alo:ahi], b[blo:bhi])
+		if !ok {
+			l.replace(alo, ahi, blo, bhi)
+			break
+		}
+		l.compare(alo, alo+x, blo, blo+y)
+		for i := 0; i < u-x; i++ {
+			l.edits = append(l.edits, edit{typ: LineUnchanged, old: alo + x + i, new: blo + y + i})
+		}
+		l.compare(alo+u, ahi, blo+v, bhi)
+	}
+
+	for i := 0; i < suffix; i++ {
+		l.edits = append(l.edits, edit{typ: LineUnchanged, old: ahi + i, new: bhi + i})
+	}
+}
+
+// replace appends edits which delete a[alo:ahi] and add b[blo:bhi].
+func (l *lineDiffer) replace(alo, ahi, blo, bhi int) {
+	for i := alo; i < ahi; i++ {
+		l.edits = append(l.edits, edit{typ: LineDeleted, old: i, new: blo})
+	}
+	for j := blo; j < bhi; j++ {
+		l.edits = append(l.edits, edit{typ: LineAdded, old: ahi, new: j})
+	}
+}
+
+// middleSnake returns the middle snake (x, y) to (u, v) of a shortest edit
+// path from a to b by searching the edit graph from both ends. a and b must
+// not be empty. ok is false if it's not found within maxSnakeCost differences
+// from each end.
+func middleSnake(a, b []string) (x, y, u, v int, ok bool) {
+	n, m := len(a), len(b)
+	delta := n - m
+	odd := delta%2 != 0
+	maxD := min((n+m+1)/2, maxSnakeCost)
+	offset := maxD + 1
+	// vf[offset+k] is the furthest x on the diagonal k (= x - y) searched
+	// forward, and vb[offset+k] is the furthest distance from the end on the
+	// diagonal k of reversed a and b searched backward.
+	vf := make([]int, 2*offset+1)
+	vb := make([]int, 2*offset+1)
+	for d := 0; d <= maxD; d++ {
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && vf[offset+k-1] < vf[offset+k+1]) {
+				x = vf[offset+k+1]
+			} else {
+				x = vf[offset+k-1] + 1
+			}
+			y := x - k
+			x0, y0 := x, y
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			vf[offset+k] = x
+			if kb := delta - k; odd && -(d-1) <= kb && kb <= d-1 && x+vb[offset+kb] >= n {
+				return x0, y0, x, y, true
+			}
+		}
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && vb[offset+k-1] < vb[offset+k+1]) {
+				x = vb[offset+k+1]
+			} else {
+				x = vb[offset+k-1] + 1
+			}
+			y := x - k
+			x0, y0 := x, y
+			for x < n && y < m && a[n-1-x] == b[m-1-y] {
+				x++
+				y++
+			}
+			vb[offset+k] = x
+			if kf := delta - k; !odd && -d <= kf && kf <= d && vf[offset+kf]+x >= n {
+				return n - x, m - y, n - x0, m - y0, true
+			}
+		}
+	}
+	return 0, 0, 0, 0, false
+}
@@ -0,0 +1,179 @@
+package diff
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestUnified(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new string
+		want     string
+	}{
+		{
+			name: "same",
+			old:  "a\nb\n",
+			new:  "a\nb\n",
+			want: "",
+		},
+		{
+			name: "modify with context",
+			old:  "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n",
+			new:  "1\n2\n3\n4\nfive\n6\n7\n8\n9\n10\n",
+			want: `--- a/f
++++ b/f
+@@ -2,7 +2,7 @@
+ 2
+ 3
+ 4
+-5
++five
+ 6
+ 7
+ 8
+`,
+		},
+		{
+			name: "separate hunks",
+			old:  "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n",
+			new:  "one\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n13\n",
+			want: `--- a/f
++++ b/f
+@@ -1,4 +1,4 @@
+-1
++one
+ 2
+ 3
+ 4
+@@ -10,3 +10,4 @@
+ 10
+ 11
+ 12
++13
+`,
+		},
+		{
+			name: "insert into empty",
+			old:  "",
+			new:  "a\n",
+			want: `--- a/f
++++ b/f
+@@ -0,0 +1,1 @@
++a
+`,
+		},
+		{
+			name: "no newline at end of file",
+			old:  "a\nb",
+			new:  "a\nb\n",
+			want: `--- a/f
++++ b/f
+@@ -1,2 +1,2 @@
+ a
+-b
+\ No newline at end of file
++b
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := string(Unified("a/f", "b/f", []byte(tt.old), []byte(tt.new)))
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("Unified() diff (-got +want):\n%s", diff)
+			}
+			if got == "" {
+				return
+			}
+			if _, err := ParseFile(strings.NewReader(got)); err != nil {
+				t.Errorf("failed to parse the result: %v", err)
+			}
+		})
+	}
+}
+
+func TestLineEdits(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	randomLines := func() []string {
+		lines := make([]string, r.Intn(30))
+		for i := range lines {
+			lines[i] = string(rune('a' + r.Intn(4)))
+		}
+		return lines
+	}
+	for n := 0; n < 1000; n++ {
+		a, b := randomLines(), randomLines()
+		if got, want := checkLineEdits(t, a, b), len(a)+len(b)-2*lcsLen(a, b); got != want {
+			t.Fatalf("lineEdits(%q, %q): got %d changes, want %d", a, b, got, want)
+		}
+	}
+}
+
+func TestLineEdits_rewritten(t *testing.T) {
+	// Lines which need more differences than the cost limit are replaced as a
+	// whole even if they have a common line.
+	const n = 20000
+	a, b := make([]string, n), make([]string, n)
+	for i := range n {
+		a[i] = fmt.Sprintf("a%d\n", i)
+		b[i] = fmt.Sprintf("b%d\n", i)
+	}
+	b[n/2] = a[n/2]
+	if got := checkLineEdits(t, a, b); got != 2*n {
+		t.Errorf("got %d changes, want %d", got, 2*n)
+	}
+}
+
+// checkLineEdits checks that lineEdits(a, b) is an edit script from a to b
+// and returns the number of added and deleted lines.
+func checkLineEdits(t *testing.T, a, b []string) int {
+	t.Helper()
+	// i and j are the numbers of old and new lines consumed by edits.
+	i, j, changes := 0, 0, 0
+	for _, e := range lineEdits(a, b) {
+		if e.old != i || e.new != j {
+			t.Fatalf("got %+v at old=%d, new=%d", e, i, j)
+		}
+		switch e.typ {
+		case LineUnchanged:
+			if a[i] != b[j] {
+				t.Fatalf("unchanged lines differ: %+v", e)
+			}
+			i++
+			j++
+		case LineDeleted:
+			i++
+			changes++
+		case LineAdded:
+			j++
+			changes++
+		}
+	}
+	if i != len(a) || j != len(b) {
+		t.Fatalf("consumed %d old and %d new lines, want %d and %d", i, j, len(a), len(b))
+	}
+	return changes
+}
+
+// lcsLen returns the length of the longest common subsequence of a and b.
+func lcsLen(a, b []string) int {
+	dp := make([][]int, len(a)+1)
+	for i := range dp {
+		dp[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				dp[i][j] = dp[i+1][j+1] + 1
+			} else {
+				dp[i][j] = max(dp[i+1][j], dp[i][j+1])
+			}
+		}
+	}
+	return dp[0][0]
+}
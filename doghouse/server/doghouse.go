@@ -65,6 +65,10 @@ func (ch *Checker) Check(ctx context.Context) (*doghouse.CheckResponse, error) {
 		Level:    ch.req.Level,
 	}
 	for _, f := range filtered {
+		// Check annotations cannot be on the old file (e.g. deleted lines).
+		if f.Diagnostic.GetLocation().GetOld() {
+			continue
+		}
 		if err := checkService.Post(ctx, &reviewdog.Comment{
 			Result:   f,
 			ToolName: ch.req.Name,
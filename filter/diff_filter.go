@@ -77,6 +77,10 @@ type DiffFilter struct {
 
 	difflines difflines
 	difffiles difffiles
+
+	// Same as difflines and difffiles but for old paths and line numbers.
+	oldlines difflines
+	oldfiles difffiles
 }
 
 // difflines is a hash table of normalized path to line number to *diff.Line.
@@ -93,6 +97,8 @@ func NewDiffFilter(diff []*diff.FileDiff, strip int, cwd string, mode Mode) *Dif
 		mode:      mode,
 		difflines: make(difflines),
 		difffiles: make(difffiles),
+		oldlines:  make(difflines),
+		oldfiles:  make(difffiles),
 	}
 	// If cwd is empty, projectRelPath should not have any meaningful data too.
 	if cwd != "" {
@@ -118,6 +124,21 @@ func (df *DiffFilter) addDiff(filediffs []*diff.FileDiff) {
 			}
 		}
 		df.difflines[path] = lines
+
+		oldpath := pathutil.NormalizeDiffPath(filediff.PathOld, df.strip)
+		df.oldfiles[oldpath] = filediff
+		oldlines, ok := df.oldlines[oldpath]
+		if !ok {
+			oldlines = make(map[int]*diff.Line)
+		}
+		for _, hunk := range filediff.Hunks {
+			for _, line := range hunk.Lines {
+				if line.LnumOld > 0 {
+					oldlines[line.LnumOld] = line
+				}
+			}
+		}
+		df.oldlines[oldpath] = oldlines
 	}
 }
 
@@ -126,8 +147,20 @@ func (df *DiffFilter) addDiff(filediffs []*diff.FileDiff) {
 //
 // Path should be normalized before calling this function.
 func (df *DiffFilter) ShouldReport(path string, lnum int) (bool, *diff.FileDiff, *diff.Line) {
-	file := df.difffiles[path]
-	lines, ok := df.difflines[path]
+	return df.shouldReport(df.difffiles, df.difflines, path, lnum)
+}
+
+// ShouldReportOld is same as ShouldReport but for the given old path and
+// lnum. e.g. deleted lines are significant in ModeAdded.
+//
+// Path should be normalized before calling this function.
+func (df *DiffFilter) ShouldReportOld(path string, lnum int) (bool, *diff.FileDiff, *diff.Line) {
+	return df.shouldReport(df.oldfiles, df.oldlines, path, lnum)
+}
+
+func (df *DiffFilter) shouldReport(files difffiles, difflines difflines, path string, lnum int) (bool, *diff.FileDiff, *diff.Line) {
+	file := files[path]
+	lines, ok := difflines[path]
 	if !ok {
 		return df.mode == ModeNoFilter, file, nil
 	}
@@ -159,7 +192,8 @@ func (df *DiffFilter) isSignificantLine(line *diff.Line) bool {
 	case ModeDiffContext, ModeFile, ModeNoFilter:
 		return true // any lines in diff are significant.
 	case ModeAdded, ModeDefault:
-		return line.Type == diff.LineAdded
+		// Added lines for new paths and deleted lines for old paths.
+		return line.Type != diff.LineUnchanged
 	}
 	return false
 }
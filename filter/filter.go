@@ -30,6 +30,9 @@ type FilteredDiagnostic struct {
 
 	OldPath string
 	OldLine int
+	// NewPath is the path of the new file for results in the old file
+	// (Location.old). It's the old path if the file is deleted.
+	NewPath string
 }
 
 // FilterCheck filters check results by diff. It doesn't drop check which
@@ -48,9 +51,13 @@ func FilterCheck(results []*rdf.Diagnostic, diff []*diff.FileDiff, strip int,
 		if endLine == 0 {
 			endLine = startLine
 		}
+		shouldReportFunc := df.ShouldReport
+		if loc.GetOld() {
+			shouldReportFunc = df.ShouldReportOld
+		}
 		check.InDiffContext = true
 		for l := startLine; l <= endLine; l++ {
-			shouldReport, difffile, diffline := df.ShouldReport(loc.GetPath(), l)
+			shouldReport, difffile, diffline := shouldReportFunc(loc.GetPath(), l)
 			check.ShouldReport = check.ShouldReport || shouldReport
 			// all lines must be in diff.
 			check.InDiffContext = check.InDiffContext && diffline != nil
@@ -59,14 +66,24 @@ func FilterCheck(results []*rdf.Diagnostic, diff []*diff.FileDiff, strip int,
 			}
 			if difffile != nil {
 				check.InDiffFile = true
-				if l == startLine {
+				if l == startLine && loc.GetOld() {
+					check.OldPath, check.OldLine = loc.GetPath(), l
+					check.NewPath = pathutil.NormalizeDiffPath(difffile.PathNew, strip)
+					if check.NewPath == "" {
+						check.NewPath = loc.GetPath()
+					}
+				} else if l == startLine {
 					// TODO(haya14busa): Support endline as well especially for GitLab.
 					check.OldPath, check.OldLine = getOldPosition(difffile, strip, loc.GetPath(), l)
 				}
 			}
 		}
-		// Add source lines for suggestions.
+		// Add source lines for suggestions. Suggestions are not applicable to
+		// the old file.
 		for i, s := range result.GetSuggestions() {
+			if loc.GetOld() {
+				break
+			}
 			inDiffContext := true
 			start := int(s.GetRange().GetStart().GetLine())
 			end := int(s.GetRange().GetEnd().GetLine())
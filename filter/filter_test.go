@@ -577,6 +577,69 @@ func findFileDiff(filediffs []*diff.FileDiff, path string, strip int) *diff.File
 	return nil
 }
 
+func TestFilterCheck_old(t *testing.T) {
+	results := []*rdf.Diagnostic{
+		{
+			Message: "deleted line",
+			Location: &rdf.Location{
+				Path:  "sample.old.txt",
+				Range: &rdf.Range{Start: &rdf.Position{Line: 2}},
+				Old:   true,
+			},
+			Suggestions: []*rdf.Suggestion{{
+				Range: &rdf.Range{Start: &rdf.Position{Line: 2}, End: &rdf.Position{Line: 3}},
+			}},
+		},
+		{
+			Message: "unchanged line",
+			Location: &rdf.Location{
+				Path:  "sample.old.txt",
+				Range: &rdf.Range{Start: &rdf.Position{Line: 1}},
+				Old:   true,
+			},
+		},
+		{
+			Message: "new path",
+			Location: &rdf.Location{
+				Path:  "sample.new.txt",
+				Range: &rdf.Range{Start: &rdf.Position{Line: 2}},
+				Old:   true,
+			},
+		},
+	}
+	want := []*FilteredDiagnostic{
+		{
+			Diagnostic:    results[0],
+			ShouldReport:  true,
+			InDiffFile:    true,
+			InDiffContext: true,
+			SourceLines:   map[int]string{2: "deleted line"},
+			OldPath:       "sample.old.txt",
+			OldLine:       2,
+			NewPath:       "sample.new.txt",
+		},
+		{
+			Diagnostic:    results[1],
+			ShouldReport:  false,
+			InDiffFile:    true,
+			InDiffContext: true,
+			SourceLines:   map[int]string{1: "unchanged, contextual line"},
+			OldPath:       "sample.old.txt",
+			OldLine:       1,
+			NewPath:       "sample.new.txt",
+		},
+		{
+			Diagnostic:  results[2],
+			SourceLines: map[int]string{},
+		},
+	}
+	filediffs, _ := diff.ParseMultiFile(strings.NewReader(diffContent))
+	got := FilterCheck(results, filediffs, 0, "", ModeAdded)
+	if value := cmp.Diff(got, want, protocmp.Transform()); value != "" {
+		t.Error(value)
+	}
+}
+
 func TestGetOldPosition(t *testing.T) {
 	const strip = 0
 	filediffs, _ := diff.ParseMultiFile(strings.NewReader(diffContent))
@@ -0,0 +1,68 @@
+package parser
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/reviewdog/reviewdog/proto/rdf"
+)
+
+var _ Parser = &AzureLogIssueParser{}
+
+// AzureLogIssueParser is parser for Azure Pipelines logging commands of
+// task.logissue (e.g. `##vso[task.logissue type=error;sourcepath=app.js;linenumber=1]msg`).
+// Other lines are ignored.
+//
+// References:
+//   - https://learn.microsoft.com/en-us/azure/devops/pipelines/scripts/logging-commands#logissue-log-an-error-or-warning
+type AzureLogIssueParser struct{}
+
+// NewAzureLogIssueParser returns a new AzureLogIssueParser.
+func NewAzureLogIssueParser() *AzureLogIssueParser {
+	return &AzureLogIssueParser{}
+}
+
+var azureLogIssueRe = regexp.MustCompile(`##vso\[task\.logissue(?:\s+([^\]]*))?\](.*)$`)
+
+var (
+	azureDataUnescaper     = strings.NewReplacer("%AZP25", "%", "%0D", "\r", "%0A", "\n")
+	azurePropertyUnescaper = strings.NewReplacer("%AZP25", "%", "%0D", "\r", "%0A", "\n", "%3B", ";", "%5D", "]")
+)
+
+func (p *AzureLogIssueParser) Parse(r io.Reader) ([]*rdf.Diagnostic, error) {
+	var ds []*rdf.Diagnostic
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		m := azureLogIssueRe.FindStringSubmatch(s.Text())
+		if m == nil {
+			continue
+		}
+		props := map[string]string{}
+		for _, kv := range strings.Split(m[1], ";") {
+			if k, v, ok := strings.Cut(kv, "="); ok {
+				props[strings.ToLower(strings.TrimSpace(k))] = azurePropertyUnescaper.Replace(v)
+			}
+		}
+		d := &rdf.Diagnostic{
+			Message:        azureDataUnescaper.Replace(m[2]),
+			Severity:       severity(props["type"]),
+			OriginalOutput: s.Text(),
+		}
+		if path := props["sourcepath"]; path != "" {
+			d.Location = &rdf.Location{
+				Path:  path,
+				Range: annotationRange(atoi(props["linenumber"]), atoi(props["columnnumber"]), 0, 0),
+			}
+		}
+		if code := props["code"]; code != "" {
+			d.Code = &rdf.Code{Value: code}
+		}
+		ds = append(ds, d)
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return ds, nil
+}
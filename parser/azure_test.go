@@ -0,0 +1,53 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/reviewdog/reviewdog/proto/rdf"
+)
+
+func TestAzureLogIssueParser(t *testing.T) {
+	const sample = `Starting: lint
+##vso[task.logissue type=error;sourcepath=src/app.js;linenumber=10;columnnumber=5;code=semi;]Missing semicolon.%0A100%AZP25
+##vso[task.logissue type=warning;sourcepath=src/a%3Bb.js;linenumber=3]Long function.
+##vso[task.logissue type=warning]Deprecated option.
+##vso[task.setvariable variable=x]1
+`
+	got, err := NewAzureLogIssueParser().Parse(strings.NewReader(sample))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*rdf.Diagnostic{
+		{
+			Message: "Missing semicolon.\n100%",
+			Location: &rdf.Location{
+				Path:  "src/app.js",
+				Range: &rdf.Range{Start: &rdf.Position{Line: 10, Column: 5}},
+			},
+			Severity:       rdf.Severity_ERROR,
+			Code:           &rdf.Code{Value: "semi"},
+			OriginalOutput: "##vso[task.logissue type=error;sourcepath=src/app.js;linenumber=10;columnnumber=5;code=semi;]Missing semicolon.%0A100%AZP25",
+		},
+		{
+			Message: "Long function.",
+			Location: &rdf.Location{
+				Path:  "src/a;b.js",
+				Range: &rdf.Range{Start: &rdf.Position{Line: 3}},
+			},
+			Severity:       rdf.Severity_WARNING,
+			OriginalOutput: "##vso[task.logissue type=warning;sourcepath=src/a%3Bb.js;linenumber=3]Long function.",
+		},
+		{
+			Message:        "Deprecated option.",
+			Severity:       rdf.Severity_WARNING,
+			OriginalOutput: "##vso[task.logissue type=warning]Deprecated option.",
+		},
+	}
+	if diff := cmp.Diff(got, want, protocmp.Transform()); diff != "" {
+		t.Errorf("diff (-got +want):\n%s", diff)
+	}
+}
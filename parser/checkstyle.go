@@ -4,6 +4,9 @@ import (
 	"encoding/xml"
 	"fmt"
 	"io"
+	"regexp"
+	"strings"
+	"text/template"
 
 	"github.com/reviewdog/reviewdog/proto/rdf"
 )
@@ -11,11 +14,24 @@ import (
 var _ Parser = &CheckStyleParser{}
 
 // CheckStyleParser is checkstyle parser.
-type CheckStyleParser struct{}
+type CheckStyleParser struct {
+	sources []*checkStyleSource
+}
 
 // NewCheckStyleParser returns a new CheckStyleParser.
 func NewCheckStyleParser() Parser {
-	return &CheckStyleParser{}
+	return &CheckStyleParser{sources: defaultCheckStyleSources}
+}
+
+// NewCheckStyleParserWithSources returns a new CheckStyleParser which
+// normalizes `source` attributes with given sources in addition to
+// DefaultCheckStyleSources. Given sources take precedence.
+func NewCheckStyleParserWithSources(sources []*CheckStyleSource) (Parser, error) {
+	ss, err := compileCheckStyleSources(sources)
+	if err != nil {
+		return nil, err
+	}
+	return &CheckStyleParser{sources: append(ss, defaultCheckStyleSources...)}, nil
 }
 
 func (p *CheckStyleParser) Parse(r io.Reader) ([]*rdf.Diagnostic, error) {
@@ -29,27 +45,169 @@ func (p *CheckStyleParser) Parse(r io.Reader) ([]*rdf.Diagnostic, error) {
 			d := &rdf.Diagnostic{
 				Location: &rdf.Location{
 					Path: file.Name,
-					Range: &rdf.Range{
-						Start: &rdf.Position{
-							Line:   int32(cerr.Line),
-							Column: int32(cerr.Column),
-						},
-					},
 				},
 				Message:  cerr.Message,
 				Severity: severity(cerr.Severity),
-				OriginalOutput: fmt.Sprintf("%v:%d:%d: %v: %v (%v)",
-					file.Name, cerr.Line, cerr.Column, cerr.Severity, cerr.Message, cerr.Source),
+			}
+			if cerr.Line > 0 {
+				d.Location.Range = &rdf.Range{
+					Start: &rdf.Position{
+						Line:   int32(cerr.Line),
+						Column: int32(cerr.Column),
+					},
+				}
+				d.OriginalOutput = fmt.Sprintf("%v:%d:%d: %v: %v (%v)",
+					file.Name, cerr.Line, cerr.Column, cerr.Severity, cerr.Message, cerr.Source)
+			} else {
+				// File-level error.
+				d.OriginalOutput = fmt.Sprintf("%v: %v: %v (%v)",
+					file.Name, cerr.Severity, cerr.Message, cerr.Source)
 			}
 			if s := cerr.Source; s != "" {
-				d.Code = &rdf.Code{Value: s}
+				d.Code, d.Source = p.normalizeSource(s)
 			}
 			ds = append(ds, d)
 		}
+		for _, exc := range file.Exceptions {
+			msg := strings.TrimSpace(exc.Text)
+			if msg == "" {
+				continue
+			}
+			ds = append(ds, &rdf.Diagnostic{
+				Location:       &rdf.Location{Path: file.Name},
+				Message:        msg,
+				Severity:       rdf.Severity_ERROR,
+				OriginalOutput: fmt.Sprintf("%v: exception: %v", file.Name, msg),
+			})
+		}
 	}
 	return ds, nil
 }
 
+// normalizeSource converts `source` attribute to rule code and tool source.
+func (p *CheckStyleParser) normalizeSource(s string) (*rdf.Code, *rdf.Source) {
+	for _, src := range p.sources {
+		m := src.re.FindStringSubmatch(s)
+		if m == nil {
+			continue
+		}
+		data := map[string]string{"source": s}
+		for i, name := range src.re.SubexpNames() {
+			if name != "" {
+				data[name] = m[i]
+			}
+		}
+		code := &rdf.Code{Value: s}
+		if rule, ok := data["rule"]; ok {
+			code.Value = rule
+		} else if len(m) > 1 {
+			code.Value = m[1]
+		}
+		data["rule"] = code.Value
+		if src.url != nil {
+			var url strings.Builder
+			if err := src.url.Execute(&url, data); err == nil {
+				code.Url = url.String()
+			}
+		}
+		var source *rdf.Source
+		if src.Name != "" {
+			source = &rdf.Source{Name: src.Name}
+		}
+		return code, source
+	}
+	return &rdf.Code{Value: s}, nil
+}
+
+// CheckStyleSource represents a rule to normalize `source` attributes of
+// checkstyle errors produced by a tool.
+type CheckStyleSource struct {
+	// Tool name which is used as source of diagnostics. (e.g. `eslint`)
+	Name string
+	// Regexp which matches `source` attribute. Submatch named `rule` (or the
+	// first submatch) is used as rule code. (e.g. `^eslint\.rules\.(?P<rule>.+)$`)
+	Pattern string
+	// Template of rule document URL in text/template. Submatches are
+	// available by name in addition to `.rule` and `.source`, and `lower`
+	// function is available. (e.g. `https://eslint.org/docs/latest/rules/{{.rule}}`)
+	URL string
+}
+
+// DefaultCheckStyleSources are built-in sources for well-known checkstyle
+// producers.
+var DefaultCheckStyleSources = []*CheckStyleSource{
+	{
+		// Core rules. e.g. eslint.rules.no-unused-vars
+		Name:    "eslint",
+		Pattern: `^eslint\.rules\.(?P<rule>[\w-]+)$`,
+		URL:     "https://eslint.org/docs/latest/rules/{{.rule}}",
+	},
+	{
+		// Plugin rules. e.g. eslint.rules.react/jsx-key
+		Name:    "eslint",
+		Pattern: `^eslint\.rules\.(?P<rule>.+)$`,
+	},
+	{
+		// e.g. com.puppycrawl.tools.checkstyle.checks.whitespace.FileTabCharacterCheck
+		Name:    "checkstyle",
+		Pattern: `^com\.puppycrawl\.tools\.checkstyle\.checks\.(?P<category>\w+)\.(?P<rule>\w+?)(?:Check)?$`,
+		URL:     "https://checkstyle.org/checks/{{.category}}/{{lower .rule}}.html",
+	},
+	{
+		// e.g. com.puppycrawl.tools.checkstyle.checks.NewlineAtEndOfFileCheck
+		Name:    "checkstyle",
+		Pattern: `^com\.puppycrawl\.tools\.checkstyle\.checks\.(?P<rule>\w+?)(?:Check)?$`,
+	},
+	{
+		// e.g. standard:no-wildcard-imports
+		Name:    "ktlint",
+		Pattern: `^(?P<rule>(?:standard|experimental):[\w-]+)$`,
+	},
+	{
+		// Standard.Category.Sniff.Code. e.g. Generic.Files.LineLength.TooLong,
+		// PSR2.Methods.FunctionCallSignature.SpaceBeforeOpenBracket
+		Name:    "phpcs",
+		Pattern: `^(?P<rule>[A-Z][A-Za-z0-9]*\.[A-Z][A-Za-z0-9]*\.[A-Z][A-Za-z0-9]*)\.[A-Za-z]\w*$`,
+	},
+}
+
+var defaultCheckStyleSources = mustCompileCheckStyleSources(DefaultCheckStyleSources)
+
+type checkStyleSource struct {
+	*CheckStyleSource
+	re  *regexp.Regexp
+	url *template.Template
+}
+
+func compileCheckStyleSources(sources []*CheckStyleSource) ([]*checkStyleSource, error) {
+	ss := make([]*checkStyleSource, 0, len(sources))
+	for _, src := range sources {
+		re, err := regexp.Compile(src.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("checkstyle source %q: invalid pattern: %w", src.Name, err)
+		}
+		s := &checkStyleSource{CheckStyleSource: src, re: re}
+		if src.URL != "" {
+			t, err := template.New(src.Name).Funcs(template.FuncMap{"lower": strings.ToLower}).
+				Option("missingkey=error").Parse(src.URL)
+			if err != nil {
+				return nil, fmt.Errorf("checkstyle source %q: invalid url template: %w", src.Name, err)
+			}
+			s.url = t
+		}
+		ss = append(ss, s)
+	}
+	return ss, nil
+}
+
+func mustCompileCheckStyleSources(sources []*CheckStyleSource) []*checkStyleSource {
+	ss, err := compileCheckStyleSources(sources)
+	if err != nil {
+		panic(err)
+	}
+	return ss
+}
+
 // CheckStyleResult represents checkstyle XML result.
 // <?xml version="1.0" encoding="utf-8"?><checkstyle version="4.3"><file ...></file>...</checkstyle>
 //
@@ -64,15 +222,22 @@ type CheckStyleResult struct {
 
 // CheckStyleFile represents <file name="fname"><error ... />...</file>
 type CheckStyleFile struct {
-	Name   string             `xml:"name,attr"`
-	Errors []*CheckStyleError `xml:"error"`
+	Name       string                 `xml:"name,attr"`
+	Errors     []*CheckStyleError     `xml:"error"`
+	Exceptions []*CheckStyleException `xml:"exception,omitempty"`
 }
 
 // CheckStyleError represents <error line="1" column="10" severity="error" message="msg" source="src" />
 type CheckStyleError struct {
 	Column   int    `xml:"column,attr,omitempty"`
-	Line     int    `xml:"line,attr"`
+	Line     int    `xml:"line,attr,omitempty"`
 	Message  string `xml:"message,attr"`
 	Severity string `xml:"severity,attr,omitempty"`
 	Source   string `xml:"source,attr,omitempty"`
 }
+
+// CheckStyleException represents <exception><![CDATA[stack trace]]></exception>
+// which is reported when a tool fails to process a file.
+type CheckStyleException struct {
+	Text string `xml:",chardata"`
+}
@@ -5,8 +5,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/reviewdog/reviewdog/proto/rdf"
 )
 
 func ExampleCheckStyleParser() {
@@ -36,8 +41,12 @@ func ExampleCheckStyleParser() {
 	//     }
 	//   },
 	//   "severity": "ERROR",
+	//   "source": {
+	//     "name": "eslint"
+	//   },
 	//   "code": {
-	//     "value": "eslint.rules.no-unused-vars"
+	//     "value": "no-unused-vars",
+	//     "url": "https://eslint.org/docs/latest/rules/no-unused-vars"
 	//   },
 	//   "originalOutput": "/path/to/file:1:10: error: 'addOne' is defined but never used. (no-unused-vars) (eslint.rules.no-unused-vars)"
 	// }
@@ -53,8 +62,12 @@ func ExampleCheckStyleParser() {
 	//     }
 	//   },
 	//   "severity": "ERROR",
+	//   "source": {
+	//     "name": "eslint"
+	//   },
 	//   "code": {
-	//     "value": "eslint.rules.use-isnan"
+	//     "value": "use-isnan",
+	//     "url": "https://eslint.org/docs/latest/rules/use-isnan"
 	//   },
 	//   "originalOutput": "/path/to/file:2:9: error: Use the isNaN function to compare with NaN. (use-isnan) (eslint.rules.use-isnan)"
 	// }
@@ -70,8 +83,12 @@ func ExampleCheckStyleParser() {
 	//     }
 	//   },
 	//   "severity": "ERROR",
+	//   "source": {
+	//     "name": "eslint"
+	//   },
 	//   "code": {
-	//     "value": "eslint.rules.space-unary-ops"
+	//     "value": "space-unary-ops",
+	//     "url": "https://eslint.org/docs/latest/rules/space-unary-ops"
 	//   },
 	//   "originalOutput": "/path/to/file:3:16: error: Unexpected space before unary operator '++'. (space-unary-ops) (eslint.rules.space-unary-ops)"
 	// }
@@ -87,8 +104,12 @@ func ExampleCheckStyleParser() {
 	//     }
 	//   },
 	//   "severity": "WARNING",
+	//   "source": {
+	//     "name": "eslint"
+	//   },
 	//   "code": {
-	//     "value": "eslint.rules.semi"
+	//     "value": "semi",
+	//     "url": "https://eslint.org/docs/latest/rules/semi"
 	//   },
 	//   "originalOutput": "/path/to/file:3:20: warning: Missing semicolon. (semi) (eslint.rules.semi)"
 	// }
@@ -104,8 +125,12 @@ func ExampleCheckStyleParser() {
 	//     }
 	//   },
 	//   "severity": "WARNING",
+	//   "source": {
+	//     "name": "eslint"
+	//   },
 	//   "code": {
-	//     "value": "eslint.rules.no-else-return"
+	//     "value": "no-else-return",
+	//     "url": "https://eslint.org/docs/latest/rules/no-else-return"
 	//   },
 	//   "originalOutput": "/path/to/file:4:12: warning: Unnecessary 'else' after 'return'. (no-else-return) (eslint.rules.no-else-return)"
 	// }
@@ -121,8 +146,12 @@ func ExampleCheckStyleParser() {
 	//     }
 	//   },
 	//   "severity": "WARNING",
+	//   "source": {
+	//     "name": "eslint"
+	//   },
 	//   "code": {
-	//     "value": "eslint.rules.indent"
+	//     "value": "indent",
+	//     "url": "https://eslint.org/docs/latest/rules/indent"
 	//   },
 	//   "originalOutput": "/path/to/file:5:7: warning: Expected indentation of 8 spaces but found 6. (indent) (eslint.rules.indent)"
 	// }
@@ -138,8 +167,12 @@ func ExampleCheckStyleParser() {
 	//     }
 	//   },
 	//   "severity": "ERROR",
+	//   "source": {
+	//     "name": "eslint"
+	//   },
 	//   "code": {
-	//     "value": "eslint.rules.consistent-return"
+	//     "value": "consistent-return",
+	//     "url": "https://eslint.org/docs/latest/rules/consistent-return"
 	//   },
 	//   "originalOutput": "/path/to/file:5:7: error: Expected a return value. (consistent-return) (eslint.rules.consistent-return)"
 	// }
@@ -155,8 +188,12 @@ func ExampleCheckStyleParser() {
 	//     }
 	//   },
 	//   "severity": "WARNING",
+	//   "source": {
+	//     "name": "eslint"
+	//   },
 	//   "code": {
-	//     "value": "eslint.rules.semi"
+	//     "value": "semi",
+	//     "url": "https://eslint.org/docs/latest/rules/semi"
 	//   },
 	//   "originalOutput": "/path/to/file:5:13: warning: Missing semicolon. (semi) (eslint.rules.semi)"
 	// }
@@ -172,9 +209,132 @@ func ExampleCheckStyleParser() {
 	//     }
 	//   },
 	//   "severity": "ERROR",
+	//   "source": {
+	//     "name": "eslint"
+	//   },
 	//   "code": {
-	//     "value": "eslint.rules.no-extra-semi"
+	//     "value": "no-extra-semi",
+	//     "url": "https://eslint.org/docs/latest/rules/no-extra-semi"
 	//   },
 	//   "originalOutput": "/path/to/file:7:2: error: Unnecessary semicolon. (no-extra-semi) (eslint.rules.no-extra-semi)"
 	// }
 }
+
+func TestCheckStyleParser_sources(t *testing.T) {
+	const sample = `<?xml version="1.0" encoding="utf-8"?>
+<checkstyle version="8.0">
+<file name="A.java">
+<error line="3" column="1" severity="warning" message="File contains tab characters." source="com.puppycrawl.tools.checkstyle.checks.whitespace.FileTabCharacterCheck"/>
+<error severity="error" message="File does not end with a newline." source="com.puppycrawl.tools.checkstyle.checks.NewlineAtEndOfFileCheck"/>
+<exception>
+<![CDATA[com.puppycrawl.tools.checkstyle.api.CheckstyleException: Exception was thrown while processing A.java]]>
+</exception>
+</file>
+<file name="a.kt">
+<error line="1" column="1" severity="error" message="Wildcard import" source="standard:no-wildcard-imports"/>
+</file>
+<file name="a.php">
+<error line="2" column="5" severity="warning" message="Line exceeds 120 characters" source="Generic.Files.LineLength.TooLong"/>
+<error line="4" column="1" severity="error" message="custom" source="mytool.R001"/>
+<error line="5" column="1" severity="error" message="not phpcs" source="PMD.design.GodClass"/>
+</file>
+</checkstyle>`
+	p, err := NewCheckStyleParserWithSources([]*CheckStyleSource{
+		{Name: "mytool", Pattern: `^mytool\.(R\d+)$`, URL: "https://example.com/rules/{{.rule}}"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := p.Parse(strings.NewReader(sample))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*rdf.Diagnostic{
+		{
+			Message: "File contains tab characters.",
+			Location: &rdf.Location{
+				Path:  "A.java",
+				Range: &rdf.Range{Start: &rdf.Position{Line: 3, Column: 1}},
+			},
+			Severity: rdf.Severity_WARNING,
+			Source:   &rdf.Source{Name: "checkstyle"},
+			Code: &rdf.Code{
+				Value: "FileTabCharacter",
+				Url:   "https://checkstyle.org/checks/whitespace/filetabcharacter.html",
+			},
+			OriginalOutput: "A.java:3:1: warning: File contains tab characters. (com.puppycrawl.tools.checkstyle.checks.whitespace.FileTabCharacterCheck)",
+		},
+		{
+			Message:        "File does not end with a newline.",
+			Location:       &rdf.Location{Path: "A.java"},
+			Severity:       rdf.Severity_ERROR,
+			Source:         &rdf.Source{Name: "checkstyle"},
+			Code:           &rdf.Code{Value: "NewlineAtEndOfFile"},
+			OriginalOutput: "A.java: error: File does not end with a newline. (com.puppycrawl.tools.checkstyle.checks.NewlineAtEndOfFileCheck)",
+		},
+		{
+			Message:        "com.puppycrawl.tools.checkstyle.api.CheckstyleException: Exception was thrown while processing A.java",
+			Location:       &rdf.Location{Path: "A.java"},
+			Severity:       rdf.Severity_ERROR,
+			OriginalOutput: "A.java: exception: com.puppycrawl.tools.checkstyle.api.CheckstyleException: Exception was thrown while processing A.java",
+		},
+		{
+			Message: "Wildcard import",
+			Location: &rdf.Location{
+				Path:  "a.kt",
+				Range: &rdf.Range{Start: &rdf.Position{Line: 1, Column: 1}},
+			},
+			Severity:       rdf.Severity_ERROR,
+			Source:         &rdf.Source{Name: "ktlint"},
+			Code:           &rdf.Code{Value: "standard:no-wildcard-imports"},
+			OriginalOutput: "a.kt:1:1: error: Wildcard import (standard:no-wildcard-imports)",
+		},
+		{
+			Message: "Line exceeds 120 characters",
+			Location: &rdf.Location{
+				Path:  "a.php",
+				Range: &rdf.Range{Start: &rdf.Position{Line: 2, Column: 5}},
+			},
+			Severity:       rdf.Severity_WARNING,
+			Source:         &rdf.Source{Name: "phpcs"},
+			Code:           &rdf.Code{Value: "Generic.Files.LineLength"},
+			OriginalOutput: "a.php:2:5: warning: Line exceeds 120 characters (Generic.Files.LineLength.TooLong)",
+		},
+		{
+			Message: "custom",
+			Location: &rdf.Location{
+				Path:  "a.php",
+				Range: &rdf.Range{Start: &rdf.Position{Line: 4, Column: 1}},
+			},
+			Severity:       rdf.Severity_ERROR,
+			Source:         &rdf.Source{Name: "mytool"},
+			Code:           &rdf.Code{Value: "R001", Url: "https://example.com/rules/R001"},
+			OriginalOutput: "a.php:4:1: error: custom (mytool.R001)",
+		},
+		{
+			// Three segments and a lowercase category don't match phpcs.
+			Message: "not phpcs",
+			Location: &rdf.Location{
+				Path:  "a.php",
+				Range: &rdf.Range{Start: &rdf.Position{Line: 5, Column: 1}},
+			},
+			Severity:       rdf.Severity_ERROR,
+			Code:           &rdf.Code{Value: "PMD.design.GodClass"},
+			OriginalOutput: "a.php:5:1: error: not phpcs (PMD.design.GodClass)",
+		},
+	}
+	if diff := cmp.Diff(got, want, protocmp.Transform()); diff != "" {
+		t.Errorf("diff (-got +want):\n%s", diff)
+	}
+}
+
+func TestNewCheckStyleParserWithSources_invalid(t *testing.T) {
+	for _, src := range []*CheckStyleSource{
+		{Name: "bad-pattern", Pattern: `(`},
+		{Name: "bad-url", Pattern: `.*`, URL: "{{.rule"},
+	} {
+		if _, err := NewCheckStyleParserWithSources([]*CheckStyleSource{src}); err == nil {
+			t.Errorf("%s: want error, got nil", src.Name)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package parser
+
+import (
+	"io"
+
+	"github.com/reviewdog/reviewdog/proto/rdf"
+)
+
+var _ Parser = &columnUnitParser{}
+
+// columnUnitParser converts columns of diagnostics parsed by the underlying
+// parser from unit to UTF-8 bytes by reading source files.
+type columnUnitParser struct {
+	p    Parser
+	unit rdf.ColumnUnit
+	// readFile reads source file content. Replaceable for testing.
+	readFile func(path string) ([]byte, error)
+}
+
+func (p *columnUnitParser) Parse(r io.Reader) ([]*rdf.Diagnostic, error) {
+	ds, err := p.p.Parse(r)
+	rdf.NormalizeColumns(ds, p.unit, p.readFile)
+	return ds, err
+}
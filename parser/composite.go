@@ -0,0 +1,231 @@
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/reviewdog/errorformat"
+
+	"github.com/reviewdog/reviewdog/proto/rdf"
+)
+
+var _ Parser = &CompositeParser{}
+
+// CompositeParser parses mixed output of multiple tools in one stream.
+//
+// Lines are routed to sub-parsers before parsing, so each sub-parser reads
+// only the lines which belong to it. Lines which start with "{" are routed to
+// JSON Lines sub-parsers (rdjsonl) if any, and other lines are routed to the
+// first line-oriented sub-parser (errorformat and CI annotation formats)
+// which matches the line. Continuation lines (%C and %Z) of a multi-line
+// errorformat message are routed to the sub-parser which started the message
+// unless another sub-parser matches them, which ends the message.
+//
+// Source of each diagnostic is set to the format name of the sub-parser which
+// matched it unless the sub-parser already sets it.
+type CompositeParser struct {
+	parsers []*subParser
+}
+
+type subParser struct {
+	name  string
+	p     Parser
+	jsonl bool
+	// efms are errorformats of an errorformat sub-parser to route lines.
+	efms []*routeEfm
+	// re matches lines of a CI annotation format sub-parser.
+	re *regexp.Regexp
+}
+
+// routeEfm is a compiled errorformat with its prefix (e.g. 'A' of %A).
+type routeEfm struct {
+	efm    *errorformat.Efm
+	prefix byte
+}
+
+// NewCompositeParser returns a new CompositeParser which combines parsers of
+// given format names. Other fields of opt are passed to each sub-parser.
+func NewCompositeParser(names []string, opt *Option) (*CompositeParser, error) {
+	if len(opt.Errorformat) > 0 {
+		return nil, errors.New("you cannot specify both format name and errorformat at the same time")
+	}
+	c := &CompositeParser{}
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return nil, errors.New("format name is empty")
+		}
+		subopt := *opt
+		subopt.FormatName = name
+		p, err := newParser(&subopt)
+		if err != nil {
+			return nil, err
+		}
+		sp := &subParser{name: name, p: p}
+		switch p := p.(type) {
+		case *RDJSONLParser:
+			sp.jsonl = true
+		case *ErrorformatParser:
+			if sp.efms, err = routeEfms(p.efms); err != nil {
+				return nil, err
+			}
+		case *WorkflowCommandParser:
+			sp.re = workflowCommandRe
+		case *AzureLogIssueParser:
+			sp.re = azureLogIssueRe
+		case *TeamCityInspectionParser:
+			sp.re = teamcityMessageRe
+		default:
+			return nil, fmt.Errorf("format %q cannot be combined with other formats", name)
+		}
+		c.parsers = append(c.parsers, sp)
+	}
+	return c, nil
+}
+
+func routeEfms(efms []string) ([]*routeEfm, error) {
+	var res []*routeEfm
+	for _, e := range efms {
+		prefix, ignore := efmPrefix(e)
+		// "%-G" ignores lines. Leave them to other sub-parsers.
+		if ignore && prefix == 'G' {
+			continue
+		}
+		efm, err := errorformat.NewEfm(e)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, &routeEfm{efm: efm, prefix: prefix})
+	}
+	return res, nil
+}
+
+// efmPrefix returns the prefix of errorformat (e.g. 'A' of %A or %-A) and
+// whether it has the "-" flag which ignores matched lines.
+func efmPrefix(efm string) (prefix byte, ignore bool) {
+	for i := 0; i < len(efm)-1; i++ {
+		if efm[i] != '%' {
+			continue
+		}
+		i++
+		c := efm[i]
+		if (c == '+' || c == '-') && i < len(efm)-1 && strings.IndexByte("DXAEWICZGOPQ", efm[i+1]) >= 0 {
+			return efm[i+1], c == '-'
+		}
+		if strings.IndexByte("DXAEWICZGOPQ", c) >= 0 {
+			return c, false
+		}
+	}
+	return 0, false
+}
+
+// match returns whether the sub-parser matches the line and the prefix of the
+// matched errorformat. Continuation errorformats (%C and %Z) are used only if
+// multiline is true.
+func (sp *subParser) match(line string, multiline bool) (bool, byte) {
+	if sp.re != nil {
+		return sp.re.MatchString(line), 0
+	}
+	for _, e := range sp.efms {
+		if (e.prefix == 'C' || e.prefix == 'Z') && !multiline {
+			continue
+		}
+		if e.efm.Match(line) != nil {
+			return true, e.prefix
+		}
+	}
+	return false, 0
+}
+
+// route returns the index of the first line-oriented sub-parser which
+// matches the line without continuation errorformats, and the prefix of the
+// matched errorformat. It returns -1 if no sub-parser matches the line.
+func (c *CompositeParser) route(line string) (int, byte) {
+	for i, sp := range c.parsers {
+		if sp.jsonl {
+			continue
+		}
+		if ok, prefix := sp.match(line, false); ok {
+			return i, prefix
+		}
+	}
+	return -1, 0
+}
+
+func (c *CompositeParser) Parse(r io.Reader) ([]*rdf.Diagnostic, error) {
+	hasJSONL := false
+	for _, sp := range c.parsers {
+		hasJSONL = hasJSONL || sp.jsonl
+	}
+	inputs := make([]bytes.Buffer, len(c.parsers))
+	// owner is the index of the sub-parser in a multi-line message.
+	owner := -1
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		line := s.Text()
+		routed := -1
+		if hasJSONL && strings.HasPrefix(strings.TrimSpace(line), "{") {
+			for i, sp := range c.parsers {
+				if sp.jsonl {
+					routed = i
+					break
+				}
+			}
+		}
+		if routed < 0 {
+			var prefix byte
+			routed, prefix = c.route(line)
+			// Continue the multi-line message unless another sub-parser
+			// matches the line.
+			if owner >= 0 && (routed < 0 || routed == owner) {
+				if ok, p := c.parsers[owner].match(line, true); ok {
+					routed, prefix = owner, p
+				}
+			}
+			switch {
+			case routed < 0 || prefix == 'Z':
+				owner = -1
+			case strings.IndexByte("AEWI", prefix) >= 0:
+				owner = routed
+			case prefix != 'C':
+				owner = -1
+			}
+		}
+		if routed >= 0 {
+			inputs[routed].WriteString(line)
+			inputs[routed].WriteByte('\n')
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+
+	var ds []*rdf.Diagnostic
+	var errs []*RecordError
+	for i, sp := range c.parsers {
+		subds, err := sp.p.Parse(&inputs[i])
+		var perr *PartialError
+		if errors.As(err, &perr) {
+			for _, e := range perr.Errors {
+				errs = append(errs, &RecordError{Line: e.Line, Err: fmt.Errorf("%s: %w", sp.name, e.Err)})
+			}
+		} else if err != nil {
+			return nil, fmt.Errorf("%s: %w", sp.name, err)
+		}
+		for _, d := range subds {
+			if d.GetSource() == nil {
+				d.Source = &rdf.Source{Name: sp.name}
+			}
+			ds = append(ds, d)
+		}
+	}
+	if len(errs) > 0 {
+		return ds, &PartialError{Errors: errs}
+	}
+	return ds, nil
+}
@@ -0,0 +1,159 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/reviewdog/reviewdog/proto/rdf"
+)
+
+func TestCompositeParser(t *testing.T) {
+	const sample = `a.go:1:2: exported func F should have comment
+src/app.ts(3,5): error TS2304: Cannot find name 'x'.
+{"message":"from rdjsonl","location":{"path":"b.go"},"source":{"name":"mytool"}}
+::warning file=c.go,line=4::from workflow command
+d.go:5: from generic
+unrelated line
+`
+	p, err := New(&Option{
+		FormatName: "golint,tsc,rdjsonl,github-workflow-command,generic",
+		Formats: []*Format{
+			{Name: "generic", Errorformat: []string{`%f:%l: %m`, `%-G%.%#`}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := p.Parse(strings.NewReader(sample))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*rdf.Diagnostic{
+		{
+			Message: "exported func F should have comment",
+			Location: &rdf.Location{
+				Path:  "a.go",
+				Range: &rdf.Range{Start: &rdf.Position{Line: 1, Column: 2}},
+			},
+			Source:         &rdf.Source{Name: "golint"},
+			OriginalOutput: "a.go:1:2: exported func F should have comment",
+		},
+		{
+			Message: "Cannot find name 'x'.",
+			Location: &rdf.Location{
+				Path:  "src/app.ts",
+				Range: &rdf.Range{Start: &rdf.Position{Line: 3, Column: 5}},
+			},
+			Severity:       rdf.Severity_ERROR,
+			Code:           &rdf.Code{Value: "2304"},
+			Source:         &rdf.Source{Name: "tsc"},
+			OriginalOutput: "src/app.ts(3,5): error TS2304: Cannot find name 'x'.",
+		},
+		{
+			Message:        "from rdjsonl",
+			Location:       &rdf.Location{Path: "b.go"},
+			Source:         &rdf.Source{Name: "mytool"},
+			OriginalOutput: `{"message":"from rdjsonl","location":{"path":"b.go"},"source":{"name":"mytool"}}`,
+		},
+		{
+			Message: "from workflow command",
+			Location: &rdf.Location{
+				Path:  "c.go",
+				Range: &rdf.Range{Start: &rdf.Position{Line: 4}},
+			},
+			Severity:       rdf.Severity_WARNING,
+			Source:         &rdf.Source{Name: "github-workflow-command"},
+			OriginalOutput: "::warning file=c.go,line=4::from workflow command",
+		},
+		{
+			// The golint line is also matched by generic, but golint wins.
+			Message: "from generic",
+			Location: &rdf.Location{
+				Path:  "d.go",
+				Range: &rdf.Range{Start: &rdf.Position{Line: 5}},
+			},
+			Source:         &rdf.Source{Name: "generic"},
+			OriginalOutput: "d.go:5: from generic",
+		},
+	}
+	if diff := cmp.Diff(got, want, protocmp.Transform()); diff != "" {
+		t.Errorf("diff (-got +want):\n%s", diff)
+	}
+}
+
+func TestCompositeParser_sameLines(t *testing.T) {
+	// Only the indented "y.go:2: detail" line is a part of the block.
+	const sample = `x.go:1: begin
+  y.go:2: detail
+y.go:2: detail
+`
+	p, err := New(&Option{
+		FormatName: "block,generic",
+		Formats: []*Format{
+			{Name: "block", Errorformat: []string{`%E%f:%l: begin`, `%Z  %m`}},
+			{Name: "generic", Errorformat: []string{`%f:%l: %m`}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := p.Parse(strings.NewReader(sample))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var outputs []string
+	for _, d := range got {
+		outputs = append(outputs, d.GetSource().GetName()+": "+d.GetOriginalOutput())
+	}
+	want := []string{
+		"block: x.go:1: begin\n  y.go:2: detail",
+		"generic: y.go:2: detail",
+	}
+	if diff := cmp.Diff(outputs, want); diff != "" {
+		t.Errorf("diff (-got +want):\n%s", diff)
+	}
+}
+
+func TestCompositeParser_multiline(t *testing.T) {
+	// "%C%m" matches any line, but lines which generic matches end the
+	// multi-line message of block.
+	const sample = `a.go:1: error: multi-line
+  detail
+b.go:2: single-line
+  not a part of the error
+`
+	p, err := New(&Option{
+		FormatName: "block,generic",
+		Formats: []*Format{
+			{Name: "block", Errorformat: []string{`%E%f:%l: error: %m`, `%C%m`}},
+			{Name: "generic", Errorformat: []string{`%f:%l: %m`}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := p.Parse(strings.NewReader(sample))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var outputs []string
+	for _, d := range got {
+		outputs = append(outputs, d.GetSource().GetName()+": "+d.GetOriginalOutput())
+	}
+	want := []string{
+		"block: a.go:1: error: multi-line\n  detail",
+		"generic: b.go:2: single-line",
+	}
+	if diff := cmp.Diff(outputs, want); diff != "" {
+		t.Errorf("diff (-got +want):\n%s", diff)
+	}
+}
+
+func TestCompositeParser_unsupportedFormat(t *testing.T) {
+	if _, err := New(&Option{FormatName: "golint,sarif"}); err == nil {
+		t.Error("want error for format which cannot be combined")
+	}
+}
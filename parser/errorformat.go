@@ -15,6 +15,10 @@ var _ Parser = &ErrorformatParser{}
 // ErrorformatParser is errorformat parser.
 type ErrorformatParser struct {
 	efm *errorformat.Errorformat
+	// efms are the errorformats in string representation if known.
+	efms []string
+	// defaultSeverity is used for diagnostics without severity.
+	defaultSeverity rdf.Severity
 }
 
 // NewErrorformatParser returns a new ErrorformatParser.
@@ -29,7 +33,9 @@ func NewErrorformatParserString(efms []string) (*ErrorformatParser, error) {
 	if err != nil {
 		return nil, err
 	}
-	return NewErrorformatParser(efm), nil
+	p := NewErrorformatParser(efm)
+	p.efms = efms
+	return p, nil
 }
 
 func (p *ErrorformatParser) Parse(r io.Reader) ([]*rdf.Diagnostic, error) {
@@ -52,6 +58,9 @@ func (p *ErrorformatParser) Parse(r io.Reader) ([]*rdf.Diagnostic, error) {
 				Severity:       severity(string(e.Type)),
 				OriginalOutput: strings.Join(e.Lines, "\n"),
 			}
+			if d.Severity == rdf.Severity_UNKNOWN_SEVERITY {
+				d.Severity = p.defaultSeverity
+			}
 			if e.Nr != 0 {
 				d.Code = &rdf.Code{Value: fmt.Sprintf("%d", e.Nr)}
 			}
@@ -0,0 +1,178 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/reviewdog/reviewdog/proto/rdf"
+)
+
+var _ Parser = &ESLintParser{}
+
+// ESLintParser is parser for ESLint JSON format (eslint -f json).
+//
+// ESLint reports columns in UTF-16 code units and ranges of fixes and
+// suggestions as offsets in the source text, so it reads the source from the
+// report ("source" field) or from the file to convert them into line/column
+// positions in UTF-8 bytes.
+type ESLintParser struct {
+	// readFile reads source file content. Replaceable for testing.
+	readFile func(path string) ([]byte, error)
+}
+
+// NewESLintParser returns a new ESLintParser.
+func NewESLintParser() *ESLintParser {
+	return &ESLintParser{readFile: os.ReadFile}
+}
+
+// ESLintFileResult represents a file result of ESLint JSON format.
+//
+// References:
+//   - https://eslint.org/docs/latest/use/formatters/#json
+//   - https://eslint.org/docs/latest/integrate/nodejs-api#-lintresult-type
+type ESLintFileResult struct {
+	FilePath string           `json:"filePath"`
+	Messages []*ESLintMessage `json:"messages"`
+	Source   *string          `json:"source,omitempty"`
+}
+
+// ESLintMessage represents a lint message of ESLint.
+type ESLintMessage struct {
+	RuleID      string                    `json:"ruleId"`
+	Severity    int                       `json:"severity"` // 1: warning, 2: error.
+	Message     string                    `json:"message"`
+	Line        int                       `json:"line"`
+	Column      int                       `json:"column"`
+	EndLine     int                       `json:"endLine,omitempty"`
+	EndColumn   int                       `json:"endColumn,omitempty"`
+	Fix         *ESLintFix                `json:"fix,omitempty"`
+	Suggestions []*ESLintSuggestionResult `json:"suggestions,omitempty"`
+}
+
+// ESLintFix represents a fix of ESLint. Range is [start, end) offsets of
+// the source text.
+type ESLintFix struct {
+	Range [2]int `json:"range"`
+	Text  string `json:"text"`
+}
+
+// ESLintSuggestionResult represents a suggestion of ESLint.
+type ESLintSuggestionResult struct {
+	Desc string     `json:"desc"`
+	Fix  *ESLintFix `json:"fix"`
+}
+
+func (p *ESLintParser) Parse(r io.Reader) ([]*rdf.Diagnostic, error) {
+	var results []*ESLintFileResult
+	if err := json.NewDecoder(r).Decode(&results); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal ESLint JSON: %w", err)
+	}
+	var ds []*rdf.Diagnostic
+	for _, file := range results {
+		src := p.source(file)
+		for _, msg := range file.Messages {
+			d := &rdf.Diagnostic{
+				Message:  msg.Message,
+				Location: &rdf.Location{Path: file.FilePath, Range: eslintRange(src, msg)},
+				Severity: eslintSeverity(msg.Severity),
+				Source:   &rdf.Source{Name: "eslint", Url: "https://eslint.org/"},
+				OriginalOutput: fmt.Sprintf("%s:%d:%d: %s (%s)",
+					file.FilePath, msg.Line, msg.Column, msg.Message, msg.RuleID),
+			}
+			if msg.RuleID != "" {
+				d.Code = &rdf.Code{Value: msg.RuleID, Url: eslintRuleURL(msg.RuleID)}
+			}
+			if src != nil {
+				d.Suggestions = eslintSuggestions(src, msg)
+			}
+			ds = append(ds, d)
+		}
+	}
+	return ds, nil
+}
+
+// source returns source text of the file result. It returns nil if the
+// source is not available.
+func (p *ESLintParser) source(file *ESLintFileResult) []byte {
+	if file.Source != nil {
+		return []byte(*file.Source)
+	}
+	if len(file.Messages) == 0 {
+		return nil
+	}
+	b, err := p.readFile(file.FilePath)
+	if err != nil {
+		return nil
+	}
+	return b
+}
+
+// eslintSuggestions returns the fix of the message as a suggestion. If the
+// message doesn't have a fix, it returns suggestions of the message, which are
+// alternatives to each other.
+func eslintSuggestions(src []byte, msg *ESLintMessage) []*rdf.Suggestion {
+	var fixes []*ESLintFix
+	if msg.Fix != nil {
+		fixes = append(fixes, msg.Fix)
+	} else {
+		for _, s := range msg.Suggestions {
+			if s.Fix != nil {
+				fixes = append(fixes, s.Fix)
+			}
+		}
+	}
+	var suggestions []*rdf.Suggestion
+	for _, fix := range fixes {
+		// ESLint offsets are indexes of JavaScript string (UTF-16 code units).
+		start, err := utf16OffsetToByteOffset(src, fix.Range[0])
+		if err != nil {
+			continue
+		}
+		end, err := utf16OffsetToByteOffset(src, fix.Range[1])
+		if err != nil {
+			continue
+		}
+		rng, err := OffsetRangeToRange(src, start, end)
+		if err != nil {
+			continue
+		}
+		suggestions = append(suggestions, &rdf.Suggestion{Range: rng, Text: fix.Text})
+	}
+	return suggestions
+}
+
+// eslintRange returns the range of the message. Columns are converted from
+// UTF-16 code units into UTF-8 bytes if src is available.
+func eslintRange(src []byte, msg *ESLintMessage) *rdf.Range {
+	rng := &rdf.Range{
+		Start: &rdf.Position{Line: int32(msg.Line), Column: int32(msg.Column)},
+	}
+	if msg.EndLine > 0 {
+		rng.End = &rdf.Position{Line: int32(msg.EndLine), Column: int32(msg.EndColumn)}
+	}
+	convertColumns(src, rng, true)
+	return rng
+}
+
+func eslintSeverity(s int) rdf.Severity {
+	switch s {
+	case 2:
+		return rdf.Severity_ERROR
+	case 1:
+		return rdf.Severity_WARNING
+	default:
+		return rdf.Severity_UNKNOWN_SEVERITY
+	}
+}
+
+// eslintRuleURL returns document URL of ESLint core rules. Plugin rules
+// (e.g. "react/jsx-key") don't have a known URL.
+func eslintRuleURL(ruleID string) string {
+	if strings.Contains(ruleID, "/") {
+		return ""
+	}
+	return "https://eslint.org/docs/latest/rules/" + ruleID
+}
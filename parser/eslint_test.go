@@ -0,0 +1,218 @@
+package parser
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/reviewdog/reviewdog/proto/rdf"
+)
+
+func TestESLintParser(t *testing.T) {
+	const sample = `[
+  {
+    "filePath": "/path/to/a.js",
+    "messages": [
+      {
+        "ruleId": "semi",
+        "severity": 2,
+        "message": "Missing semicolon.",
+        "line": 2,
+        "column": 12,
+        "endLine": 2,
+        "endColumn": 13,
+        "fix": {"range": [24, 24], "text": ";"},
+        "suggestions": [
+          {"desc": "Not used with fix.", "fix": {"range": [0, 0], "text": "x"}}
+        ]
+      },
+      {
+        "ruleId": "no-unused-vars",
+        "severity": 1,
+        "message": "'x' is assigned a value but never used.",
+        "line": 1,
+        "column": 7,
+        "endLine": 1,
+        "endColumn": 8,
+        "suggestions": [
+          {"desc": "Remove x.", "fix": {"range": [0, 13], "text": ""}}
+        ]
+      }
+    ],
+    "source": "const x = 1;\nconst y = 2\n"
+  },
+  {
+    "filePath": "/path/to/b.js",
+    "messages": [
+      {
+        "ruleId": "react/jsx-key",
+        "severity": 2,
+        "message": "Missing key.",
+        "line": 1,
+        "column": 1
+      },
+      {
+        "ruleId": "quotes",
+        "severity": 2,
+        "message": "Strings must use singlequote.",
+        "line": 1,
+        "column": 16,
+        "endLine": 1,
+        "endColumn": 19,
+        "fix": {"range": [15, 18], "text": "'a'"}
+      }
+    ]
+  },
+  {
+    "filePath": "/path/to/c.js",
+    "messages": [
+      {
+        "ruleId": null,
+        "fatal": true,
+        "severity": 2,
+        "message": "Parsing error: Unexpected token",
+        "line": 3,
+        "column": 1
+      }
+    ]
+  }
+]`
+	p := NewESLintParser()
+	p.readFile = func(path string) ([]byte, error) {
+		if path == "/path/to/b.js" {
+			return []byte(`const s = "😀"+"a";`), nil
+		}
+		return nil, errors.New("not found")
+	}
+	got, err := p.Parse(strings.NewReader(sample))
+	if err != nil {
+		t.Fatal(err)
+	}
+	eslint := &rdf.Source{Name: "eslint", Url: "https://eslint.org/"}
+	want := []*rdf.Diagnostic{
+		{
+			Message: "Missing semicolon.",
+			Location: &rdf.Location{
+				Path: "/path/to/a.js",
+				Range: &rdf.Range{
+					Start: &rdf.Position{Line: 2, Column: 12},
+					End:   &rdf.Position{Line: 2, Column: 13},
+				},
+			},
+			Severity: rdf.Severity_ERROR,
+			Source:   eslint,
+			Code:     &rdf.Code{Value: "semi", Url: "https://eslint.org/docs/latest/rules/semi"},
+			Suggestions: []*rdf.Suggestion{{
+				Range: &rdf.Range{
+					Start: &rdf.Position{Line: 2, Column: 12},
+					End:   &rdf.Position{Line: 2, Column: 12},
+				},
+				Text: ";",
+			}},
+			OriginalOutput: "/path/to/a.js:2:12: Missing semicolon. (semi)",
+		},
+		{
+			Message: "'x' is assigned a value but never used.",
+			Location: &rdf.Location{
+				Path: "/path/to/a.js",
+				Range: &rdf.Range{
+					Start: &rdf.Position{Line: 1, Column: 7},
+					End:   &rdf.Position{Line: 1, Column: 8},
+				},
+			},
+			Severity: rdf.Severity_WARNING,
+			Source:   eslint,
+			Code:     &rdf.Code{Value: "no-unused-vars", Url: "https://eslint.org/docs/latest/rules/no-unused-vars"},
+			Suggestions: []*rdf.Suggestion{{
+				Range: &rdf.Range{
+					Start: &rdf.Position{Line: 1, Column: 1},
+					End:   &rdf.Position{Line: 2, Column: 1},
+				},
+			}},
+			OriginalOutput: "/path/to/a.js:1:7: 'x' is assigned a value but never used. (no-unused-vars)",
+		},
+		{
+			Message: "Missing key.",
+			Location: &rdf.Location{
+				Path:  "/path/to/b.js",
+				Range: &rdf.Range{Start: &rdf.Position{Line: 1, Column: 1}},
+			},
+			Severity:       rdf.Severity_ERROR,
+			Source:         eslint,
+			Code:           &rdf.Code{Value: "react/jsx-key"},
+			OriginalOutput: "/path/to/b.js:1:1: Missing key. (react/jsx-key)",
+		},
+		{
+			Message: "Strings must use singlequote.",
+			Location: &rdf.Location{
+				Path: "/path/to/b.js",
+				// Columns are converted from UTF-16 code units to UTF-8 bytes.
+				Range: &rdf.Range{
+					Start: &rdf.Position{Line: 1, Column: 18},
+					End:   &rdf.Position{Line: 1, Column: 21},
+				},
+			},
+			Severity: rdf.Severity_ERROR,
+			Source:   eslint,
+			Code:     &rdf.Code{Value: "quotes", Url: "https://eslint.org/docs/latest/rules/quotes"},
+			Suggestions: []*rdf.Suggestion{{
+				// "😀" is 2 UTF-16 code units and 4 bytes in UTF-8.
+				Range: &rdf.Range{
+					Start: &rdf.Position{Line: 1, Column: 18},
+					End:   &rdf.Position{Line: 1, Column: 21},
+				},
+				Text: "'a'",
+			}},
+			OriginalOutput: "/path/to/b.js:1:16: Strings must use singlequote. (quotes)",
+		},
+		{
+			Message: "Parsing error: Unexpected token",
+			Location: &rdf.Location{
+				Path:  "/path/to/c.js",
+				Range: &rdf.Range{Start: &rdf.Position{Line: 3, Column: 1}},
+			},
+			Severity:       rdf.Severity_ERROR,
+			Source:         eslint,
+			OriginalOutput: "/path/to/c.js:3:1: Parsing error: Unexpected token ()",
+		},
+	}
+	if diff := cmp.Diff(got, want, protocmp.Transform()); diff != "" {
+		t.Errorf("diff (-got +want):\n%s", diff)
+	}
+}
+
+func TestOffsetToPosition(t *testing.T) {
+	src := []byte("abc\nd𐐀e\n")
+	tests := []struct {
+		offset  int
+		want    *rdf.Position
+		wantErr bool
+	}{
+		{offset: 0, want: &rdf.Position{Line: 1, Column: 1}},
+		{offset: 3, want: &rdf.Position{Line: 1, Column: 4}},
+		{offset: 4, want: &rdf.Position{Line: 2, Column: 1}},
+		{offset: 9, want: &rdf.Position{Line: 2, Column: 6}},
+		{offset: 11, want: &rdf.Position{Line: 3, Column: 1}},
+		{offset: 12, wantErr: true},
+		{offset: -1, wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := OffsetToPosition(src, tt.offset)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("OffsetToPosition(%d): want error, got nil", tt.offset)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("OffsetToPosition(%d): unexpected error: %v", tt.offset, err)
+			continue
+		}
+		if diff := cmp.Diff(got, tt.want, protocmp.Transform()); diff != "" {
+			t.Errorf("OffsetToPosition(%d) diff (-got +want):\n%s", tt.offset, diff)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package parser
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"unicode/utf8"
+
+	"github.com/reviewdog/reviewdog/proto/rdf"
+)
+
+// textEdit represents a replacement of a fix. Positions are 1-based and
+// columns are counted in Unicode code points.
+type textEdit struct {
+	startLine, startColumn int
+	endLine, endColumn     int
+	text                   string
+}
+
+// buildFixSuggestion converts edits of a fix into a rdf.Suggestion.
+//
+// Some tools report a fix as multiple edits (e.g. adding quotes around a
+// word), while reviewdog suggestions are applied independently. So edits are
+// merged into one suggestion which covers all of them by filling gaps between
+// edits with the source text.
+// src is used to convert columns into UTF-8 byte count and to merge edits. If
+// src is nil, it returns a suggestion only for a single edit as is.
+func buildFixSuggestion(src []byte, edits []textEdit) *rdf.Suggestion {
+	if len(edits) == 0 {
+		return nil
+	}
+	if src == nil {
+		if len(edits) != 1 {
+			return nil
+		}
+		e := edits[0]
+		return &rdf.Suggestion{
+			Range: &rdf.Range{
+				Start: &rdf.Position{Line: int32(e.startLine), Column: int32(e.startColumn)},
+				End:   &rdf.Position{Line: int32(e.endLine), Column: int32(e.endColumn)},
+			},
+			Text: e.text,
+		}
+	}
+	type offsetEdit struct {
+		start, end int
+		text       string
+	}
+	oedits := make([]offsetEdit, 0, len(edits))
+	for _, e := range edits {
+		start, err := codePointPositionToOffset(src, e.startLine, e.startColumn)
+		if err != nil {
+			return nil
+		}
+		end, err := codePointPositionToOffset(src, e.endLine, e.endColumn)
+		if err != nil || start > end {
+			return nil
+		}
+		oedits = append(oedits, offsetEdit{start: start, end: end, text: e.text})
+	}
+	sort.SliceStable(oedits, func(i, j int) bool { return oedits[i].start < oedits[j].start })
+	var text bytes.Buffer
+	for i, e := range oedits {
+		if i > 0 {
+			prev := oedits[i-1]
+			if e.start < prev.end {
+				return nil // Overlapping edits.
+			}
+			text.Write(src[prev.end:e.start])
+		}
+		text.WriteString(e.text)
+	}
+	rng, err := OffsetRangeToRange(src, oedits[0].start, oedits[len(oedits)-1].end)
+	if err != nil {
+		return nil
+	}
+	return &rdf.Suggestion{Range: rng, Text: text.String()}
+}
+
+// codePointPositionToOffset converts 1-based line and 1-based column in
+// Unicode code points to byte offset in src.
+func codePointPositionToOffset(src []byte, line, column int) (int, error) {
+	if line < 1 || column < 1 {
+		return 0, fmt.Errorf("invalid position %d:%d", line, column)
+	}
+	offset := 0
+	for l := 1; l < line; l++ {
+		i := bytes.IndexByte(src[offset:], '\n')
+		if i < 0 {
+			return 0, fmt.Errorf("line %d is out of range", line)
+		}
+		offset += i + 1
+	}
+	for c := 1; c < column; c++ {
+		if offset >= len(src) || src[offset] == '\n' {
+			return 0, fmt.Errorf("column %d is out of range at line %d", column, line)
+		}
+		_, size := utf8.DecodeRune(src[offset:])
+		offset += size
+	}
+	return offset, nil
+}
+
+// sourceCache reads and caches source files. It returns nil for files which
+// cannot be read.
+type sourceCache struct {
+	readFile func(path string) ([]byte, error)
+	srcs     map[string][]byte
+}
+
+func newSourceCache(readFile func(path string) ([]byte, error)) *sourceCache {
+	return &sourceCache{readFile: readFile, srcs: make(map[string][]byte)}
+}
+
+func (c *sourceCache) get(path string) []byte {
+	if src, ok := c.srcs[path]; ok {
+		return src
+	}
+	src, err := c.readFile(path)
+	if err != nil {
+		src = nil
+	}
+	c.srcs[path] = src
+	return src
+}
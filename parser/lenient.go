@@ -0,0 +1,141 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+
+	"github.com/reviewdog/reviewdog/proto/rdf"
+)
+
+// LenientMode represents enumeration of available lenient modes, which
+// control how parsers handle invalid records (e.g. a malformed line of
+// rdjsonl) in input.
+type LenientMode int
+
+const (
+	// LenientModeOff fails the whole parse if input has any invalid records.
+	LenientModeOff LenientMode = iota
+	// LenientModeWarning skips invalid records and logs a warning summary.
+	LenientModeWarning
+	// LenientModeDiagnostic skips invalid records and reports them as
+	// diagnostics whose source is reviewdog.
+	LenientModeDiagnostic
+)
+
+// String implements the flag.Value interface
+func (mode *LenientMode) String() string {
+	names := [...]string{
+		"off",
+		"warning",
+		"diagnostic",
+	}
+	if *mode < LenientModeOff || *mode > LenientModeDiagnostic {
+		return "Unknown lenient mode"
+	}
+	return names[*mode]
+}
+
+// Set implements the flag.Value interface
+func (mode *LenientMode) Set(value string) error {
+	switch value {
+	case "off", "":
+		*mode = LenientModeOff
+	case "warning":
+		*mode = LenientModeWarning
+	case "diagnostic":
+		*mode = LenientModeDiagnostic
+	default:
+		return fmt.Errorf("invalid lenient mode name: %s", value)
+	}
+	return nil
+}
+
+// RecordError represents an error of an invalid record in input.
+type RecordError struct {
+	// Line is 1-based line number where the record starts. 0 if unknown.
+	Line int
+	Err  error
+}
+
+func (e *RecordError) Error() string {
+	if e.Line == 0 {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *RecordError) Unwrap() error {
+	return e.Err
+}
+
+// PartialError is returned by parsers in lenient mode along with successfully
+// parsed diagnostics if some records are skipped.
+type PartialError struct {
+	Errors []*RecordError
+}
+
+func (e *PartialError) Error() string {
+	msgs := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		msgs = append(msgs, err.Error())
+	}
+	return fmt.Sprintf("skipped %d invalid record(s):\n%s", len(e.Errors), strings.Join(msgs, "\n"))
+}
+
+// recordErrors returns diagnostics with an error based on recorded errors.
+// In lenient mode, it returns *PartialError if there are any errors.
+// Otherwise, it returns all errors joined.
+func recordErrors(ds []*rdf.Diagnostic, errs []*RecordError, lenient bool) ([]*rdf.Diagnostic, error) {
+	if len(errs) == 0 {
+		return ds, nil
+	}
+	if lenient {
+		return ds, &PartialError{Errors: errs}
+	}
+	joined := make([]error, 0, len(errs))
+	for _, err := range errs {
+		joined = append(joined, err)
+	}
+	return nil, errors.Join(joined...)
+}
+
+var _ Parser = &lenientParser{}
+
+// lenientParser handles *PartialError returned by the underlying parser based
+// on LenientMode.
+type lenientParser struct {
+	p    Parser
+	name string
+	mode LenientMode
+}
+
+func (p *lenientParser) Parse(r io.Reader) ([]*rdf.Diagnostic, error) {
+	ds, err := p.p.Parse(r)
+	var perr *PartialError
+	if !errors.As(err, &perr) {
+		return ds, err
+	}
+	log.Printf("reviewdog: [%s] %v", p.name, perr)
+	if p.mode == LenientModeDiagnostic {
+		ds = append(ds, ParseErrorDiagnostics(p.name, perr)...)
+	}
+	return ds, nil
+}
+
+// ParseErrorDiagnostics converts errors of skipped records into diagnostics
+// whose source is reviewdog.
+func ParseErrorDiagnostics(format string, perr *PartialError) []*rdf.Diagnostic {
+	ds := make([]*rdf.Diagnostic, 0, len(perr.Errors))
+	for _, err := range perr.Errors {
+		ds = append(ds, &rdf.Diagnostic{
+			Message:        fmt.Sprintf("failed to parse %s input: %v", format, err),
+			Severity:       rdf.Severity_WARNING,
+			Source:         &rdf.Source{Name: "reviewdog", Url: "https://github.com/reviewdog/reviewdog"},
+			OriginalOutput: err.Error(),
+		})
+	}
+	return ds
+}
@@ -0,0 +1,155 @@
+package parser
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const lenientRDJSONLSample = `{"message":"valid","location":{"path":"a.go","range":{"start":{"line":1}}}}
+{"message":"broken json",
+{"message":"invalid line type","location":{"path":"a.go","range":{"start":{"line":"3"}}}}
+`
+
+func TestRDJSONLParser_strict(t *testing.T) {
+	_, err := (&RDJSONLParser{}).Parse(strings.NewReader(lenientRDJSONLSample))
+	if err == nil {
+		t.Fatal("want error, got nil")
+	}
+	for _, want := range []string{
+		"line 2: failed to unmarshal rdjsonl (Diagnostic)",
+		"line 3: at '/location/range/start/line': got string, want integer",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q doesn't contain %q", err, want)
+		}
+	}
+}
+
+func TestRDJSONLParser_lenient(t *testing.T) {
+	ds, err := (&RDJSONLParser{Lenient: true}).Parse(strings.NewReader(lenientRDJSONLSample))
+	var perr *PartialError
+	if !errors.As(err, &perr) {
+		t.Fatalf("want *PartialError, got %v", err)
+	}
+	if len(ds) != 1 || ds[0].GetMessage() != "valid" {
+		t.Errorf("got diagnostics %v, want only the valid one", ds)
+	}
+	var lines []int
+	for _, e := range perr.Errors {
+		lines = append(lines, e.Line)
+	}
+	if diff := cmp.Diff(lines, []int{2, 3}); diff != "" {
+		t.Errorf("error lines (-got, +want):\n%s", diff)
+	}
+}
+
+func TestRDJSONParser_lenient(t *testing.T) {
+	const sample = `{
+  "source": {"name": "linter"},
+  "diagnostics": [
+    {
+      "message": "valid",
+      "location": {"path": "a.go", "range": {"start": {"line": 1}}}
+    },
+    {
+      "message": "invalid",
+      "location": {
+        "path": "a.go",
+        "range": {"start": {"line": "2"}}
+      }
+    }
+  ]
+}`
+	_, err := (&RDJSONParser{}).Parse(strings.NewReader(sample))
+	if err == nil || !strings.Contains(err.Error(), "line 12: at '/diagnostics/1/location/range/start/line'") {
+		t.Errorf("strict mode: got error %v", err)
+	}
+
+	ds, err := (&RDJSONParser{Lenient: true}).Parse(strings.NewReader(sample))
+	var perr *PartialError
+	if !errors.As(err, &perr) {
+		t.Fatalf("want *PartialError, got %v", err)
+	}
+	if len(ds) != 1 || ds[0].GetMessage() != "valid" || ds[0].GetSource().GetName() != "linter" {
+		t.Errorf("got diagnostics %v, want only the valid one", ds)
+	}
+	if len(perr.Errors) != 1 || perr.Errors[0].Line != 12 {
+		t.Errorf("got errors %v, want an error at line 12", perr.Errors)
+	}
+}
+
+func TestSarifParser_lenient(t *testing.T) {
+	const sample = `{
+  "version": "2.1.0",
+  "runs": [{
+    "tool": {"driver": {"name": "linter"}},
+    "results": [
+      {
+        "message": {"text": "invalid"},
+        "locations": [{"physicalLocation": {"artifactLocation": {"uri": "a.go"}, "region": {"startLine": "1"}}}]
+      },
+      {
+        "message": {"text": "valid"},
+        "locations": [{"physicalLocation": {"artifactLocation": {"uri": "a.go"}, "region": {"startLine": 2}}}]
+      }
+    ]
+  }]
+}`
+	if _, err := (&SarifParser{}).Parse(strings.NewReader(sample)); err == nil {
+		t.Error("strict mode: want error, got nil")
+	}
+	ds, err := (&SarifParser{Lenient: true}).Parse(strings.NewReader(sample))
+	var perr *PartialError
+	if !errors.As(err, &perr) {
+		t.Fatalf("want *PartialError, got %v", err)
+	}
+	if len(ds) != 1 || ds[0].GetMessage() != "valid" {
+		t.Errorf("got diagnostics %v, want only the valid one", ds)
+	}
+	if len(perr.Errors) != 1 || perr.Errors[0].Line != 6 {
+		t.Errorf("got errors %v, want an error at line 6", perr.Errors)
+	}
+}
+
+func TestNew_lenient(t *testing.T) {
+	tests := []struct {
+		mode    LenientMode
+		want    []string
+		wantErr bool
+	}{
+		{mode: LenientModeOff, wantErr: true},
+		{mode: LenientModeWarning, want: []string{"valid"}},
+		{mode: LenientModeDiagnostic, want: []string{"valid", "reviewdog", "reviewdog"}},
+	}
+	for _, tt := range tests {
+		p, err := New(&Option{FormatName: "rdjsonl", Lenient: tt.mode})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ds, err := p.Parse(strings.NewReader(lenientRDJSONLSample))
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%s: want error, got nil", tt.mode.String())
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.mode.String(), err)
+			continue
+		}
+		var got []string
+		for _, d := range ds {
+			if d.GetSource().GetName() == "reviewdog" {
+				got = append(got, "reviewdog")
+			} else {
+				got = append(got, d.GetMessage())
+			}
+		}
+		if diff := cmp.Diff(got, tt.want); diff != "" {
+			t.Errorf("%s: (-got, +want):\n%s", tt.mode.String(), diff)
+		}
+	}
+}
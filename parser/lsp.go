@@ -0,0 +1,242 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/reviewdog/reviewdog/pathutil"
+	"github.com/reviewdog/reviewdog/proto/rdf"
+)
+
+var _ Parser = &LSPParser{}
+
+// LSPParser is parser for LSP PublishDiagnosticsParams in JSON. It accepts
+// a PublishDiagnosticsParams, an array of them, a textDocument/publishDiagnostics
+// notification or a stream of them (e.g. JSON Lines).
+type LSPParser struct {
+	// readFile reads source file content to convert UTF-16 based columns.
+	// Replaceable for testing.
+	readFile func(path string) ([]byte, error)
+}
+
+// NewLSPParser returns a new LSPParser.
+func NewLSPParser() *LSPParser {
+	return &LSPParser{readFile: os.ReadFile}
+}
+
+// LSPPublishDiagnosticsParams represents parameters of
+// textDocument/publishDiagnostics notification.
+//
+// References:
+//   - https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textDocument_publishDiagnostics
+type LSPPublishDiagnosticsParams struct {
+	URI         string           `json:"uri"`
+	Diagnostics []*LSPDiagnostic `json:"diagnostics"`
+}
+
+// LSPDiagnostic represents a diagnostic of LSP.
+type LSPDiagnostic struct {
+	Range              LSPRange                 `json:"range"`
+	Severity           int                      `json:"severity"`
+	Code               json.RawMessage          `json:"code"` // integer or string.
+	CodeDescription    *LSPCodeDescription      `json:"codeDescription"`
+	Source             string                   `json:"source"`
+	Message            string                   `json:"message"`
+	Tags               []int                    `json:"tags"`
+	RelatedInformation []*LSPRelatedInformation `json:"relatedInformation"`
+}
+
+// LSPRange represents a range of LSP. The end position is exclusive.
+type LSPRange struct {
+	Start LSPPosition `json:"start"`
+	End   LSPPosition `json:"end"`
+}
+
+// LSPPosition represents a position of LSP. Line is 0-based and character is
+// 0-based offset in UTF-16 code units.
+type LSPPosition struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+// LSPCodeDescription represents a description of a diagnostic code.
+type LSPCodeDescription struct {
+	Href string `json:"href"`
+}
+
+// LSPRelatedInformation represents a related location of a diagnostic.
+type LSPRelatedInformation struct {
+	Location struct {
+		URI   string   `json:"uri"`
+		Range LSPRange `json:"range"`
+	} `json:"location"`
+	Message string `json:"message"`
+}
+
+func (p *LSPParser) Parse(r io.Reader) ([]*rdf.Diagnostic, error) {
+	srcs := newSourceCache(p.readFile)
+	var ds []*rdf.Diagnostic
+	dec := json.NewDecoder(r)
+	for {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("failed to unmarshal LSP diagnostics: %w", err)
+		}
+		params, err := lspParams(raw)
+		if err != nil {
+			return nil, err
+		}
+		for _, param := range params {
+			path, err := pathutil.URIToPath(param.URI)
+			if err != nil {
+				return nil, err
+			}
+			for _, ld := range param.Diagnostics {
+				d, err := lspDiagnostic(ld, path, srcs)
+				if err != nil {
+					return nil, err
+				}
+				ds = append(ds, d)
+			}
+		}
+	}
+	return ds, nil
+}
+
+// lspParams unmarshals a JSON value of PublishDiagnosticsParams, an array of
+// them or a notification.
+func lspParams(raw json.RawMessage) ([]*LSPPublishDiagnosticsParams, error) {
+	if b := bytes.TrimSpace(raw); len(b) > 0 && b[0] == '[' {
+		var params []*LSPPublishDiagnosticsParams
+		if err := json.Unmarshal(raw, &params); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal LSP diagnostics: %w", err)
+		}
+		return params, nil
+	}
+	var notification struct {
+		Method string                       `json:"method"`
+		Params *LSPPublishDiagnosticsParams `json:"params"`
+	}
+	if err := json.Unmarshal(raw, &notification); err == nil && notification.Method != "" {
+		if notification.Method != "textDocument/publishDiagnostics" || notification.Params == nil {
+			return nil, nil
+		}
+		return []*LSPPublishDiagnosticsParams{notification.Params}, nil
+	}
+	var param LSPPublishDiagnosticsParams
+	if err := json.Unmarshal(raw, &param); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal LSP diagnostics: %w", err)
+	}
+	return []*LSPPublishDiagnosticsParams{&param}, nil
+}
+
+func lspDiagnostic(ld *LSPDiagnostic, path string, srcs *sourceCache) (*rdf.Diagnostic, error) {
+	original, err := json.Marshal(ld)
+	if err != nil {
+		return nil, err
+	}
+	message := ld.Message
+	if tags := lspTags(ld.Tags); len(tags) > 0 {
+		message = fmt.Sprintf("%s (%s)", message, strings.Join(tags, ", "))
+	}
+	d := &rdf.Diagnostic{
+		Message: message,
+		Location: &rdf.Location{
+			Path:  path,
+			Range: lspRange(srcs.get(path), ld.Range),
+		},
+		Severity:       lspSeverity(ld.Severity),
+		OriginalOutput: string(original),
+	}
+	if ld.Source != "" {
+		d.Source = &rdf.Source{Name: ld.Source}
+	}
+	if code := lspCode(ld.Code); code != "" {
+		d.Code = &rdf.Code{Value: code}
+		if ld.CodeDescription != nil {
+			d.Code.Url = ld.CodeDescription.Href
+		}
+	}
+	for _, info := range ld.RelatedInformation {
+		relPath, err := pathutil.URIToPath(info.Location.URI)
+		if err != nil {
+			return nil, err
+		}
+		d.RelatedLocations = append(d.RelatedLocations, &rdf.RelatedLocation{
+			Message: info.Message,
+			Location: &rdf.Location{
+				Path:  relPath,
+				Range: lspRange(srcs.get(relPath), info.Location.Range),
+			},
+		})
+	}
+	return d, nil
+}
+
+// lspRange converts LSP range into rdf.Range. If src is nil, UTF-16 based
+// characters are used as columns as is.
+func lspRange(src []byte, r LSPRange) *rdf.Range {
+	return &rdf.Range{
+		Start: lspPosition(src, r.Start),
+		End:   lspPosition(src, r.End),
+	}
+}
+
+func lspPosition(src []byte, p LSPPosition) *rdf.Position {
+	pos := &rdf.Position{Line: int32(p.Line + 1), Column: int32(p.Character + 1)}
+	if src == nil {
+		return pos
+	}
+	if line, ok := rdf.SourceLine(src, p.Line+1); ok {
+		if col, err := byteColumn(line, p.Character+1, true); err == nil {
+			pos.Column = int32(col)
+		}
+	}
+	return pos
+}
+
+func lspSeverity(s int) rdf.Severity {
+	switch s {
+	case 1:
+		return rdf.Severity_ERROR
+	case 2:
+		return rdf.Severity_WARNING
+	case 3, 4: // Information and Hint.
+		return rdf.Severity_INFO
+	default:
+		return rdf.Severity_UNKNOWN_SEVERITY
+	}
+}
+
+func lspCode(raw json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	var n json.Number
+	if err := json.Unmarshal(raw, &n); err == nil {
+		return n.String()
+	}
+	return ""
+}
+
+func lspTags(tags []int) []string {
+	var names []string
+	for _, tag := range tags {
+		switch tag {
+		case 1:
+			names = append(names, "unnecessary")
+		case 2:
+			names = append(names, "deprecated")
+		}
+	}
+	return names
+}
@@ -0,0 +1,92 @@
+package parser
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/reviewdog/reviewdog/proto/rdf"
+)
+
+func TestLSPParser(t *testing.T) {
+	const sample = `{
+  "uri": "file:///path/to/a%20b.ts",
+  "diagnostics": [
+    {
+      "range": {"start": {"line": 1, "character": 6}, "end": {"line": 1, "character": 9}},
+      "severity": 1,
+      "code": 2304,
+      "codeDescription": {"href": "https://example.com/2304"},
+      "source": "ts",
+      "message": "Cannot find name 'foo'.",
+      "relatedInformation": [
+        {
+          "location": {"uri": "file:///path/to/c.ts", "range": {"start": {"line": 0, "character": 0}, "end": {"line": 0, "character": 3}}},
+          "message": "Did you mean 'for'?"
+        }
+      ]
+    }
+  ]
+}
+{"jsonrpc": "2.0", "method": "textDocument/publishDiagnostics", "params": {"uri": "file:///path/to/c.ts", "diagnostics": [{"range": {"start": {"line": 0, "character": 0}, "end": {"line": 1, "character": 0}}, "severity": 4, "code": "unused", "message": "Unused.", "tags": [1, 2]}]}}
+{"jsonrpc": "2.0", "method": "window/logMessage", "params": {"type": 3, "message": "ignored"}}
+[{"uri": "file:///path/to/c.ts", "diagnostics": []}]
+`
+	p := NewLSPParser()
+	p.readFile = func(path string) ([]byte, error) {
+		if path == "/path/to/a b.ts" {
+			return []byte("let x;\n'😀'; foo;\n"), nil
+		}
+		return nil, errors.New("not found")
+	}
+	got, err := p.Parse(strings.NewReader(sample))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*rdf.Diagnostic{
+		{
+			Message: "Cannot find name 'foo'.",
+			Location: &rdf.Location{
+				Path: "/path/to/a b.ts",
+				Range: &rdf.Range{
+					// "😀" is 2 UTF-16 code units and 4 bytes in UTF-8.
+					Start: &rdf.Position{Line: 2, Column: 9},
+					End:   &rdf.Position{Line: 2, Column: 12},
+				},
+			},
+			Severity: rdf.Severity_ERROR,
+			Source:   &rdf.Source{Name: "ts"},
+			Code:     &rdf.Code{Value: "2304", Url: "https://example.com/2304"},
+			RelatedLocations: []*rdf.RelatedLocation{
+				{
+					Message: "Did you mean 'for'?",
+					Location: &rdf.Location{
+						Path: "/path/to/c.ts",
+						Range: &rdf.Range{
+							Start: &rdf.Position{Line: 1, Column: 1},
+							End:   &rdf.Position{Line: 1, Column: 4},
+						},
+					},
+				},
+			},
+		},
+		{
+			Message: "Unused. (unnecessary, deprecated)",
+			Location: &rdf.Location{
+				Path: "/path/to/c.ts",
+				Range: &rdf.Range{
+					Start: &rdf.Position{Line: 1, Column: 1},
+					End:   &rdf.Position{Line: 2, Column: 1},
+				},
+			},
+			Severity: rdf.Severity_INFO,
+			Code:     &rdf.Code{Value: "unused"},
+		},
+	}
+	if diff := cmp.Diff(got, want, protocmp.Transform(), protocmp.IgnoreFields(&rdf.Diagnostic{}, "original_output")); diff != "" {
+		t.Errorf("diff (-got +want):\n%s", diff)
+	}
+}
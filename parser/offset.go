@@ -0,0 +1,77 @@
+package parser
+
+import (
+	"bytes"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/reviewdog/reviewdog/proto/rdf"
+)
+
+// OffsetToPosition converts 0-based byte offset in src to rdf.Position (1-based
+// line and 1-based column in UTF-8 bytes).
+// offset == len(src) is valid and points to the end of the source.
+func OffsetToPosition(src []byte, offset int) (*rdf.Position, error) {
+	if offset < 0 || offset > len(src) {
+		return nil, fmt.Errorf("offset %d is out of range [0, %d]", offset, len(src))
+	}
+	line := bytes.Count(src[:offset], []byte{'\n'}) + 1
+	lineStart := bytes.LastIndexByte(src[:offset], '\n') + 1
+	return &rdf.Position{
+		Line:   int32(line),
+		Column: int32(offset-lineStart) + 1,
+	}, nil
+}
+
+// OffsetRangeToRange converts [start, end) byte offset range in src to
+// rdf.Range.
+func OffsetRangeToRange(src []byte, start, end int) (*rdf.Range, error) {
+	if start > end {
+		return nil, fmt.Errorf("invalid offset range [%d, %d)", start, end)
+	}
+	s, err := OffsetToPosition(src, start)
+	if err != nil {
+		return nil, err
+	}
+	e, err := OffsetToPosition(src, end)
+	if err != nil {
+		return nil, err
+	}
+	return &rdf.Range{Start: s, End: e}, nil
+}
+
+// utf16OffsetToByteOffset converts 0-based offset in UTF-16 code units (e.g.
+// index of JavaScript string) to byte offset in src.
+func utf16OffsetToByteOffset(src []byte, offset int) (int, error) {
+	units := 0
+	for i := 0; i < len(src); {
+		if units >= offset {
+			if units > offset {
+				return 0, fmt.Errorf("UTF-16 offset %d points to the middle of a character", offset)
+			}
+			return i, nil
+		}
+		r, size := utf8.DecodeRune(src[i:])
+		if r >= 0x10000 {
+			units += 2 // surrogate pair
+		} else {
+			units++
+		}
+		i += size
+	}
+	if units != offset {
+		return 0, fmt.Errorf("UTF-16 offset %d is out of range [0, %d]", offset, units)
+	}
+	return len(src), nil
+}
+
+// byteColumn converts 1-based column in line counted in Unicode code points
+// (or UTF-16 code units if utf16 is true) to 1-based column in UTF-8 bytes.
+// column == (length of line) + 1 is valid and points to the end of the line.
+func byteColumn(line []byte, column int, utf16 bool) (int, error) {
+	unit := rdf.ColumnUnitCodePoint
+	if utf16 {
+		unit = rdf.ColumnUnitUTF16
+	}
+	return rdf.ByteColumn(line, column, unit)
+}
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"os"
+	"strings"
 
 	"github.com/reviewdog/errorformat/fmts"
 
@@ -19,30 +21,145 @@ type Parser interface {
 // Option represents option to create Parser. Either FormatName or
 // Errorformat should be specified.
 type Option struct {
+	// FormatName is a format name. Comma separated format names (e.g.
+	// `golint,eslint`) create CompositeParser for mixed output.
 	FormatName  string
 	Errorformat []string
 	DiffStrip   int
+	// SarifSkipUnchanged skips SARIF results whose baselineState is "unchanged".
+	SarifSkipUnchanged bool
+	// Lenient controls how to handle invalid records in input. Supported by
+	// rdjson, rdjsonl and sarif.
+	Lenient LenientMode
+	// Formats are user-defined named errorformats. They take precedence over
+	// pre-defined errorformats.
+	Formats []*Format
+	// CheckStyleSources are rules to normalize `source` attributes of
+	// checkstyle input. They take precedence over DefaultCheckStyleSources.
+	CheckStyleSources []*CheckStyleSource
+	// ColumnUnit is the unit of columns in input. Columns are converted to
+	// UTF-8 bytes by reading source files. It's ignored by formats which
+	// convert columns by themselves (sarif, lsp, eslint-json, ruff-json and
+	// shellcheck-json1).
+	ColumnUnit rdf.ColumnUnit
+}
+
+// Format represents a user-defined named errorformat.
+type Format struct {
+	// Format name which can be used as FormatName. (e.g. `mylinter`)
+	Name string
+	// Description of the format.
+	Description string
+	// errorformat. (e.g. `%f:%l:%c:%m`, `%-G%.%#`)
+	Errorformat []string
+	// Default severity for diagnostics without severity. ("info", "warning", "error")
+	Severity string
 }
 
 // New returns Parser based on Option.
 func New(opt *Option) (Parser, error) {
+	p, err := newParser(opt)
+	if err != nil {
+		return nil, err
+	}
+	partial := supportsPartialResults(p)
+	if opt.ColumnUnit != rdf.ColumnUnitByte && !convertsColumns(p) {
+		p = &columnUnitParser{p: p, unit: opt.ColumnUnit, readFile: os.ReadFile}
+	}
+	if opt.Lenient == LenientModeOff || !partial {
+		return p, nil
+	}
+	return &lenientParser{p: p, name: opt.FormatName, mode: opt.Lenient}, nil
+}
+
+// convertsColumns returns true if p converts columns into UTF-8 bytes by
+// itself. ColumnUnit is ignored for such parsers.
+func convertsColumns(p Parser) bool {
+	switch p.(type) {
+	case *SarifParser, *LSPParser, *ESLintParser, *RuffParser, *ShellCheckParser:
+		return true
+	}
+	return false
+}
+
+// supportsPartialResults returns true if p may return *PartialError in lenient
+// mode.
+func supportsPartialResults(p Parser) bool {
+	switch p := p.(type) {
+	case *RDJSONParser, *RDJSONLParser, *SarifParser:
+		return true
+	case *CompositeParser:
+		for _, sp := range p.parsers {
+			if supportsPartialResults(sp.p) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IsBuiltinFormat returns true if name is a format name supported without
+// user-defined formats. Comma separated format names are not supported.
+func IsBuiltinFormat(name string) bool {
+	switch name {
+	case "checkstyle", "rdjsonl", "rdjson", "diff", "sarif", "eslint-json",
+		"shellcheck-json1", "ruff-json", "github-workflow-command",
+		"azure-logissue", "teamcity-inspection", "lsp":
+		return true
+	}
+	_, ok := fmts.DefinedFmts()[name]
+	return ok
+}
+
+func newParser(opt *Option) (Parser, error) {
 	name := opt.FormatName
+	lenient := opt.Lenient != LenientModeOff
 
 	if name != "" && len(opt.Errorformat) > 0 {
 		return nil, errors.New("you cannot specify both format name and errorformat at the same time")
 	}
 
+	if strings.Contains(name, ",") {
+		return NewCompositeParser(strings.Split(name, ","), opt)
+	}
+
 	switch name {
 	case "checkstyle":
-		return NewCheckStyleParser(), nil
+		return NewCheckStyleParserWithSources(opt.CheckStyleSources)
 	case "rdjsonl":
-		return NewRDJSONLParser(), nil
+		return &RDJSONLParser{Lenient: lenient}, nil
 	case "rdjson":
-		return NewRDJSONParser(), nil
+		return &RDJSONParser{Lenient: lenient}, nil
 	case "diff":
 		return NewDiffParser(opt.DiffStrip), nil
 	case "sarif":
-		return NewSarifParser(), nil
+		return &SarifParser{SkipUnchanged: opt.SarifSkipUnchanged, Lenient: lenient}, nil
+	case "eslint-json":
+		return NewESLintParser(), nil
+	case "shellcheck-json1":
+		return NewShellCheckParser(), nil
+	case "ruff-json":
+		return NewRuffParser(), nil
+	case "github-workflow-command":
+		return NewWorkflowCommandParser(), nil
+	case "azure-logissue":
+		return NewAzureLogIssueParser(), nil
+	case "teamcity-inspection":
+		return NewTeamCityInspectionParser(), nil
+	case "lsp":
+		return NewLSPParser(), nil
+	}
+
+	// use user-defined errorformat
+	for _, f := range opt.Formats {
+		if f.Name == name && name != "" {
+			p, err := NewErrorformatParserString(f.Errorformat)
+			if err != nil {
+				return nil, fmt.Errorf("format %q: %w", name, err)
+			}
+			p.defaultSeverity = severity(f.Severity)
+			return p, nil
+		}
 	}
 
 	// use defined errorformat
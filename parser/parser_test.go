@@ -2,7 +2,10 @@ package parser
 
 import (
 	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/reviewdog/reviewdog/proto/rdf"
 )
 
 func TestNewParser(t *testing.T) {
@@ -41,6 +44,48 @@ func TestNewParser(t *testing.T) {
 			},
 			typ: &SarifParser{},
 		},
+		{
+			in: &Option{
+				FormatName: "eslint-json",
+			},
+			typ: &ESLintParser{},
+		},
+		{
+			in: &Option{
+				FormatName: "shellcheck-json1",
+			},
+			typ: &ShellCheckParser{},
+		},
+		{
+			in: &Option{
+				FormatName: "ruff-json",
+			},
+			typ: &RuffParser{},
+		},
+		{
+			in: &Option{
+				FormatName: "github-workflow-command",
+			},
+			typ: &WorkflowCommandParser{},
+		},
+		{
+			in: &Option{
+				FormatName: "azure-logissue",
+			},
+			typ: &AzureLogIssueParser{},
+		},
+		{
+			in: &Option{
+				FormatName: "teamcity-inspection",
+			},
+			typ: &TeamCityInspectionParser{},
+		},
+		{
+			in: &Option{
+				FormatName: "lsp",
+			},
+			typ: &LSPParser{},
+		},
 		{ // empty
 			in:      &Option{},
 			wantErr: true,
@@ -52,6 +97,47 @@ func TestNewParser(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			in: &Option{
+				FormatName: "rdjsonl",
+				Lenient:    LenientModeWarning,
+			},
+			typ: &lenientParser{},
+		},
+		{ // errorformat doesn't support lenient mode.
+			in: &Option{
+				FormatName: "golint",
+				Lenient:    LenientModeWarning,
+			},
+			typ: &ErrorformatParser{},
+		},
+		{
+			in: &Option{
+				FormatName: "golint,rdjsonl",
+				Lenient:    LenientModeWarning,
+			},
+			typ: &lenientParser{},
+		},
+		{
+			in: &Option{
+				FormatName: "golint",
+				ColumnUnit: rdf.ColumnUnitUTF16,
+			},
+			typ: &columnUnitParser{},
+		},
+		{ // sarif converts columns by itself.
+			in: &Option{
+				FormatName: "sarif",
+				ColumnUnit: rdf.ColumnUnitUTF16,
+			},
+			typ: &SarifParser{},
+		},
+		{
+			in: &Option{
+				FormatName: "golint,rdjsonl",
+			},
+			typ: &CompositeParser{},
+		},
 		{ // unsupported
 			in: &Option{
 				FormatName: "unsupported format",
@@ -73,3 +159,29 @@ func TestNewParser(t *testing.T) {
 		}
 	}
 }
+
+func TestNewParser_userDefinedFormat(t *testing.T) {
+	p, err := New(&Option{
+		FormatName: "mylint",
+		Formats: []*Format{
+			{Name: "golint", Errorformat: []string{`%f:%l:%c: %m`}},
+			{Name: "mylint", Errorformat: []string{`%f:%l: %t%*[^:]: %m`, `%f:%l: %m`}, Severity: "warning"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ds, err := p.Parse(strings.NewReader("a.go:1: error: broken\nb.go:2: unused\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []rdf.Severity{rdf.Severity_ERROR, rdf.Severity_WARNING}
+	if len(ds) != len(want) {
+		t.Fatalf("got %d diagnostics, want %d", len(ds), len(want))
+	}
+	for i, d := range ds {
+		if d.GetSeverity() != want[i] {
+			t.Errorf("diagnostics[%d].Severity = %v, want %v", i, d.GetSeverity(), want[i])
+		}
+	}
+}
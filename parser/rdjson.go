@@ -1,8 +1,10 @@
 package parser
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
+	"strconv"
 
 	"google.golang.org/protobuf/encoding/protojson"
 
@@ -12,7 +14,12 @@ import (
 var _ Parser = &RDJSONParser{}
 
 // RDJSONParser is parser for rdjsonl format.
-type RDJSONParser struct{}
+type RDJSONParser struct {
+	// Lenient skips invalid diagnostics instead of failing. Parse returns
+	// *PartialError along with valid diagnostics if any diagnostics are
+	// skipped.
+	Lenient bool
+}
 
 // NewRDJSONParser returns a new RDJSONParser.
 func NewRDJSONParser() *RDJSONParser {
@@ -21,13 +28,27 @@ func NewRDJSONParser() *RDJSONParser {
 
 // Parse parses rdjson (JSON of DiagnosticResult).
 func (p *RDJSONParser) Parse(r io.Reader) ([]*rdf.Diagnostic, error) {
+	if err := loadSchemas(); err != nil {
+		return nil, err
+	}
 	b, err := io.ReadAll(r)
 	if err != nil {
 		return nil, err
 	}
 	var dr rdf.DiagnosticResult
-	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(b, &dr); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal rdjson (DiagnosticResult): %w", err)
+	var errs []*RecordError
+	if p.Lenient {
+		errs, err = unmarshalRDJSONLenient(b, &dr)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(b, &dr); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal rdjson (DiagnosticResult): %w", err)
+		}
+		if errs := validateSchema(diagnosticResultSchema, b, 1); len(errs) > 0 {
+			return recordErrors(nil, errs, false)
+		}
 	}
 	for _, d := range dr.Diagnostics {
 		// Fill in default severity and source for each diagnostic.
@@ -42,5 +63,46 @@ func (p *RDJSONParser) Parse(r io.Reader) ([]*rdf.Diagnostic, error) {
 			d.OriginalOutput = d.String()
 		}
 	}
-	return dr.Diagnostics, nil
+	return recordErrors(dr.Diagnostics, errs, p.Lenient)
+}
+
+// unmarshalRDJSONLenient unmarshals rdjson into dr, skipping invalid
+// diagnostics. It returns errors of skipped diagnostics. It still fails if
+// fields other than diagnostics are invalid.
+func unmarshalRDJSONLenient(b []byte, dr *rdf.DiagnosticResult) ([]*RecordError, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal rdjson (DiagnosticResult): %w", err)
+	}
+	rawDiagnostics := fields["diagnostics"]
+	delete(fields, "diagnostics")
+	rest, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(rest, dr); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal rdjson (DiagnosticResult): %w", err)
+	}
+	if len(rawDiagnostics) == 0 {
+		return nil, nil
+	}
+	var raws []json.RawMessage
+	if err := json.Unmarshal(rawDiagnostics, &raws); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal rdjson (DiagnosticResult): diagnostics: %w", err)
+	}
+	var errs []*RecordError
+	for i, raw := range raws {
+		line := jsonPointerLine(b, []string{"diagnostics", strconv.Itoa(i)})
+		d := new(rdf.Diagnostic)
+		if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(raw, d); err != nil {
+			errs = append(errs, &RecordError{Line: line, Err: fmt.Errorf("failed to unmarshal rdjson (Diagnostic): %w", err)})
+			continue
+		}
+		if verrs := validateSchema(diagnosticSchema, raw, line); len(verrs) > 0 {
+			errs = append(errs, verrs...)
+			continue
+		}
+		dr.Diagnostics = append(dr.Diagnostics, d)
+	}
+	return errs, nil
 }
@@ -13,7 +13,12 @@ import (
 var _ Parser = &RDJSONLParser{}
 
 // RDJSONLParser is parser for rdjsonl format.
-type RDJSONLParser struct{}
+type RDJSONLParser struct {
+	// Lenient skips invalid lines instead of failing. Parse returns
+	// *PartialError along with diagnostics of valid lines if any lines are
+	// skipped.
+	Lenient bool
+}
 
 // NewRDJSONLParser returns a new RDJSONParser.
 func NewRDJSONLParser() *RDJSONLParser {
@@ -22,12 +27,21 @@ func NewRDJSONLParser() *RDJSONLParser {
 
 // Parse parses rdjson (JSONL of Diagnostic).
 func (p *RDJSONLParser) Parse(r io.Reader) ([]*rdf.Diagnostic, error) {
+	if err := loadSchemas(); err != nil {
+		return nil, err
+	}
 	var results []*rdf.Diagnostic
+	var errs []*RecordError
 	s := bufio.NewScanner(r)
-	for s.Scan() {
+	for line := 1; s.Scan(); line++ {
 		d := new(rdf.Diagnostic)
 		if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(s.Bytes(), d); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal rdjsonl (Diagnostic): %w", err)
+			errs = append(errs, &RecordError{Line: line, Err: fmt.Errorf("failed to unmarshal rdjsonl (Diagnostic): %w", err)})
+			continue
+		}
+		if verrs := validateSchema(diagnosticSchema, s.Bytes(), line); len(verrs) > 0 {
+			errs = append(errs, verrs...)
+			continue
 		}
 		if d.GetOriginalOutput() == "" {
 			// TODO(haya14busa): Refactor not to fill in original output.
@@ -35,5 +49,8 @@ func (p *RDJSONLParser) Parse(r io.Reader) ([]*rdf.Diagnostic, error) {
 		}
 		results = append(results, d)
 	}
-	return results, nil
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return recordErrors(results, errs, p.Lenient)
 }
@@ -0,0 +1,133 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/reviewdog/reviewdog/proto/rdf"
+)
+
+var _ Parser = &RuffParser{}
+
+// RuffParser is parser for Ruff JSON format (ruff check --output-format=json).
+type RuffParser struct {
+	// readFile reads source file content. Replaceable for testing.
+	readFile func(path string) ([]byte, error)
+}
+
+// NewRuffParser returns a new RuffParser.
+func NewRuffParser() *RuffParser {
+	return &RuffParser{readFile: os.ReadFile}
+}
+
+// RuffDiagnostic represents a diagnostic of Ruff JSON output.
+//
+// References:
+//   - https://docs.astral.sh/ruff/settings/#output-format
+type RuffDiagnostic struct {
+	Code        *string       `json:"code"` // null for syntax errors.
+	URL         *string       `json:"url"`
+	Message     string        `json:"message"`
+	Filename    string        `json:"filename"`
+	Cell        *int          `json:"cell"` // Jupyter Notebook cell.
+	Location    *RuffLocation `json:"location"`
+	EndLocation *RuffLocation `json:"end_location"`
+	Fix         *RuffFix      `json:"fix"`
+}
+
+// RuffLocation represents a position of Ruff. Column is 1-based and counted
+// in Unicode code points.
+type RuffLocation struct {
+	Row    int `json:"row"`
+	Column int `json:"column"`
+}
+
+// RuffFix represents a fix of Ruff.
+type RuffFix struct {
+	Applicability string      `json:"applicability"` // safe, unsafe or display-only.
+	Message       *string     `json:"message"`
+	Edits         []*RuffEdit `json:"edits"`
+}
+
+// RuffEdit represents an edit of Ruff fix.
+type RuffEdit struct {
+	Content     string        `json:"content"`
+	Location    *RuffLocation `json:"location"`
+	EndLocation *RuffLocation `json:"end_location"`
+}
+
+func (p *RuffParser) Parse(r io.Reader) ([]*rdf.Diagnostic, error) {
+	var results []*RuffDiagnostic
+	if err := json.NewDecoder(r).Decode(&results); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal Ruff JSON: %w", err)
+	}
+	srcs := newSourceCache(p.readFile)
+	var ds []*rdf.Diagnostic
+	for _, rd := range results {
+		d := &rdf.Diagnostic{
+			Message: rd.Message,
+			Location: &rdf.Location{
+				Path:  rd.Filename,
+				Range: ruffRange(rd.Location, rd.EndLocation),
+			},
+			// Ruff doesn't have severity. Treat syntax errors as error and
+			// others as warning.
+			Severity: rdf.Severity_WARNING,
+			Source:   &rdf.Source{Name: "ruff", Url: "https://docs.astral.sh/ruff/"},
+		}
+		code := ""
+		if rd.Code != nil {
+			code = *rd.Code
+			d.Code = &rdf.Code{Value: code}
+			if rd.URL != nil {
+				d.Code.Url = *rd.URL
+			}
+		} else {
+			d.Severity = rdf.Severity_ERROR
+		}
+		msg := rd.Message
+		if code != "" {
+			msg = code + " " + msg
+		}
+		d.OriginalOutput = fmt.Sprintf("%s:%d:%d: %s", rd.Filename,
+			d.GetLocation().GetRange().GetStart().GetLine(),
+			d.GetLocation().GetRange().GetStart().GetColumn(), msg)
+		// Locations of notebooks are relative to cells, not to the file.
+		if rd.Cell == nil {
+			convertColumns(srcs.get(rd.Filename), d.GetLocation().GetRange(), false)
+		}
+		if f := rd.Fix; f != nil && f.Applicability != "display-only" && rd.Cell == nil {
+			edits := make([]textEdit, 0, len(f.Edits))
+			for _, e := range f.Edits {
+				if e.Location == nil || e.EndLocation == nil {
+					continue
+				}
+				edits = append(edits, textEdit{
+					startLine: e.Location.Row, startColumn: e.Location.Column,
+					endLine: e.EndLocation.Row, endColumn: e.EndLocation.Column,
+					text: e.Content,
+				})
+			}
+			if s := buildFixSuggestion(srcs.get(rd.Filename), edits); s != nil {
+				d.Suggestions = []*rdf.Suggestion{s}
+			}
+		}
+		ds = append(ds, d)
+	}
+	return ds, nil
+}
+
+func ruffRange(start, end *RuffLocation) *rdf.Range {
+	if start == nil {
+		return nil
+	}
+	rng := &rdf.Range{
+		Start: &rdf.Position{Line: int32(start.Row), Column: int32(start.Column)},
+	}
+	if end != nil {
+		rng.End = &rdf.Position{Line: int32(end.Row), Column: int32(end.Column)}
+	}
+	return rng
+}
@@ -0,0 +1,163 @@
+package parser
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/reviewdog/reviewdog/proto/rdf"
+)
+
+func TestRuffParser(t *testing.T) {
+	const sample = `[
+  {
+    "cell": null,
+    "code": "F401",
+    "end_location": {"column": 10, "row": 1},
+    "filename": "/path/to/a.py",
+    "fix": {
+      "applicability": "safe",
+      "edits": [{"content": "", "end_location": {"column": 1, "row": 2}, "location": {"column": 1, "row": 1}}],
+      "message": "Remove unused import: ` + "`os`" + `"
+    },
+    "location": {"column": 8, "row": 1},
+    "message": "` + "`os`" + ` imported but unused",
+    "noqa_row": 1,
+    "url": "https://docs.astral.sh/ruff/rules/unused-import"
+  },
+  {
+    "cell": null,
+    "code": "E225",
+    "end_location": {"column": 9, "row": 3},
+    "filename": "/path/to/a.py",
+    "fix": {
+      "applicability": "safe",
+      "edits": [
+        {"content": " ", "end_location": {"column": 8, "row": 3}, "location": {"column": 8, "row": 3}},
+        {"content": " ", "end_location": {"column": 9, "row": 3}, "location": {"column": 9, "row": 3}}
+      ],
+      "message": "Add missing whitespace"
+    },
+    "location": {"column": 8, "row": 3},
+    "message": "Missing whitespace around operator",
+    "noqa_row": 3,
+    "url": "https://docs.astral.sh/ruff/rules/missing-whitespace-around-operator"
+  },
+  {
+    "cell": null,
+    "code": "B006",
+    "end_location": {"column": 5, "row": 2},
+    "filename": "/path/to/a.py",
+    "fix": {
+      "applicability": "display-only",
+      "edits": [{"content": "None", "end_location": {"column": 5, "row": 2}, "location": {"column": 1, "row": 2}}],
+      "message": "Replace with ` + "`None`" + `"
+    },
+    "location": {"column": 1, "row": 2},
+    "message": "Do not use mutable data structures for argument defaults",
+    "noqa_row": 2,
+    "url": "https://docs.astral.sh/ruff/rules/mutable-argument-default"
+  },
+  {
+    "cell": null,
+    "code": null,
+    "end_location": {"column": 1, "row": 5},
+    "filename": "/path/to/b.py",
+    "fix": null,
+    "location": {"column": 1, "row": 5},
+    "message": "SyntaxError: Expected an expression",
+    "noqa_row": null,
+    "url": null
+  }
+]`
+	p := NewRuffParser()
+	p.readFile = func(path string) ([]byte, error) {
+		if path == "/path/to/a.py" {
+			return []byte("import os\nimport sys\ns = \"é\"+b\n"), nil
+		}
+		return nil, errors.New("not found")
+	}
+	got, err := p.Parse(strings.NewReader(sample))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ruff := &rdf.Source{Name: "ruff", Url: "https://docs.astral.sh/ruff/"}
+	want := []*rdf.Diagnostic{
+		{
+			Message: "`os` imported but unused",
+			Location: &rdf.Location{
+				Path: "/path/to/a.py",
+				Range: &rdf.Range{
+					Start: &rdf.Position{Line: 1, Column: 8},
+					End:   &rdf.Position{Line: 1, Column: 10},
+				},
+			},
+			Severity: rdf.Severity_WARNING,
+			Source:   ruff,
+			Code:     &rdf.Code{Value: "F401", Url: "https://docs.astral.sh/ruff/rules/unused-import"},
+			Suggestions: []*rdf.Suggestion{{
+				Range: &rdf.Range{
+					Start: &rdf.Position{Line: 1, Column: 1},
+					End:   &rdf.Position{Line: 2, Column: 1},
+				},
+			}},
+			OriginalOutput: "/path/to/a.py:1:8: F401 `os` imported but unused",
+		},
+		{
+			Message: "Missing whitespace around operator",
+			Location: &rdf.Location{
+				Path: "/path/to/a.py",
+				// "é" is 2 bytes in UTF-8.
+				Range: &rdf.Range{
+					Start: &rdf.Position{Line: 3, Column: 9},
+					End:   &rdf.Position{Line: 3, Column: 10},
+				},
+			},
+			Severity: rdf.Severity_WARNING,
+			Source:   ruff,
+			Code:     &rdf.Code{Value: "E225", Url: "https://docs.astral.sh/ruff/rules/missing-whitespace-around-operator"},
+			Suggestions: []*rdf.Suggestion{{
+				// "é" is 2 bytes in UTF-8.
+				Range: &rdf.Range{
+					Start: &rdf.Position{Line: 3, Column: 9},
+					End:   &rdf.Position{Line: 3, Column: 10},
+				},
+				Text: " + ",
+			}},
+			OriginalOutput: "/path/to/a.py:3:8: E225 Missing whitespace around operator",
+		},
+		{
+			Message: "Do not use mutable data structures for argument defaults",
+			Location: &rdf.Location{
+				Path: "/path/to/a.py",
+				Range: &rdf.Range{
+					Start: &rdf.Position{Line: 2, Column: 1},
+					End:   &rdf.Position{Line: 2, Column: 5},
+				},
+			},
+			Severity:       rdf.Severity_WARNING,
+			Source:         ruff,
+			Code:           &rdf.Code{Value: "B006", Url: "https://docs.astral.sh/ruff/rules/mutable-argument-default"},
+			OriginalOutput: "/path/to/a.py:2:1: B006 Do not use mutable data structures for argument defaults",
+		},
+		{
+			Message: "SyntaxError: Expected an expression",
+			Location: &rdf.Location{
+				Path: "/path/to/b.py",
+				Range: &rdf.Range{
+					Start: &rdf.Position{Line: 5, Column: 1},
+					End:   &rdf.Position{Line: 5, Column: 1},
+				},
+			},
+			Severity:       rdf.Severity_ERROR,
+			Source:         ruff,
+			OriginalOutput: "/path/to/b.py:5:1: SyntaxError: Expected an expression",
+		},
+	}
+	if diff := cmp.Diff(got, want, protocmp.Transform()); diff != "" {
+		t.Errorf("diff (-got +want):\n%s", diff)
+	}
+}
@@ -1,11 +1,14 @@
 package parser
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 
 	"github.com/haya14busa/go-sarif/sarif"
 	"github.com/reviewdog/reviewdog/proto/rdf"
@@ -15,7 +18,20 @@ import (
 var _ Parser = &SarifParser{}
 
 // SarifParser is sarif parser.
-type SarifParser struct{}
+type SarifParser struct {
+	// SkipUnchanged skips results whose baselineState is "unchanged", which
+	// means the result was also detected in the baseline run.
+	SkipUnchanged bool
+
+	// Lenient skips invalid results instead of failing. Parse returns
+	// *PartialError along with diagnostics of valid results if any results
+	// are skipped.
+	Lenient bool
+
+	// readFile reads source file content to convert columns. Replaceable for
+	// testing. os.ReadFile is used if nil.
+	readFile func(path string) ([]byte, error)
+}
 
 // NewSarifParser returns a new SarifParser.
 func NewSarifParser() Parser {
@@ -23,8 +39,20 @@ func NewSarifParser() Parser {
 }
 
 func (p *SarifParser) Parse(r io.Reader) ([]*rdf.Diagnostic, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var errs []*RecordError
+	var resultLines map[[2]int]int
+	if p.Lenient {
+		b, errs, resultLines, err = sanitizeSarif(b)
+		if err != nil {
+			return nil, err
+		}
+	}
 	slf := new(sarif.Sarif)
-	if err := json.NewDecoder(r).Decode(slf); err != nil {
+	if err := json.NewDecoder(bytes.NewReader(b)).Decode(slf); err != nil {
 		return nil, err
 	}
 	var ds []*rdf.Diagnostic
@@ -35,112 +63,308 @@ func (p *SarifParser) Parse(r io.Reader) ([]*rdf.Diagnostic, error) {
 	if root, err := serviceutil.GetGitRoot(); err == nil {
 		basedir = root
 	}
-	for _, run := range slf.Runs {
+	readFile := p.readFile
+	if readFile == nil {
+		readFile = os.ReadFile
+	}
+	srcs := newSourceCache(func(path string) ([]byte, error) {
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(basedir, path)
+		}
+		return readFile(path)
+	})
+	for i, run := range slf.Runs {
 		tool := run.Tool
 		driver := tool.Driver
-		name := driver.Name
 		informationURI := ""
 		if driver.InformationURI != nil {
 			informationURI = *driver.InformationURI
 		}
-		baseURIs := run.OriginalURIBaseIDS
-		rules := map[string]sarif.ReportingDescriptor{}
+		// SARIF columns are counted in Unicode code points or UTF-16 code
+		// units while rdf columns are counted in UTF-8 bytes.
+		utf16 := run.ColumnKind != nil && *run.ColumnKind == sarif.Utf16CodeUnits
+		rc := &sarifRunContext{
+			name:           driver.Name,
+			informationURI: informationURI,
+			baseURIs:       run.OriginalURIBaseIDS,
+			basedir:        basedir,
+			rules:          map[string]sarif.ReportingDescriptor{},
+			convert: func(path string, rng *rdf.Range) {
+				convertColumns(srcs.get(path), rng, utf16)
+			},
+		}
 		for _, rule := range driver.Rules {
-			rules[rule.ID] = rule
+			rc.rules[rule.ID] = rule
 		}
-		for _, result := range run.Results {
-			original, err := json.Marshal(result)
-			if err != nil {
-				return nil, err
+		for j, result := range run.Results {
+			if isSuppressed(result) {
+				continue
+			}
+			if p.SkipUnchanged && result.BaselineState != nil &&
+				*result.BaselineState == sarif.Unchanged {
+				continue
 			}
-			message := getText(result.Message)
-			rule := sarif.ReportingDescriptor{}
-			ruleID := ""
-			if result.RuleID != nil {
-				ruleID = *result.RuleID
+			rds, err := rc.diagnostics(result)
+			if err != nil {
+				if !p.Lenient {
+					return nil, err
+				}
+				errs = append(errs, &RecordError{Line: resultLines[[2]int{i, j}], Err: err})
+				continue
 			}
-			rule = rules[ruleID]
-			level := ""
-			if result.Level != nil {
-				level = string(*result.Level)
-			} else if rule.DefaultConfiguration != nil && rule.DefaultConfiguration.Level != nil {
-				level = string(*rule.DefaultConfiguration.Level)
+			ds = append(ds, rds...)
+		}
+	}
+	return recordErrors(ds, errs, p.Lenient)
+}
+
+// sarifRunContext holds information of a SARIF run to convert its results.
+type sarifRunContext struct {
+	name           string
+	informationURI string
+	baseURIs       map[string]sarif.ArtifactLocation
+	basedir        string
+	rules          map[string]sarif.ReportingDescriptor
+	// convert converts columns of the range in the file into UTF-8 bytes.
+	convert func(path string, rng *rdf.Range)
+}
+
+// diagnostics converts a SARIF result into diagnostics. It returns a
+// diagnostic for each location of the result.
+func (rc *sarifRunContext) diagnostics(result sarif.Result) ([]*rdf.Diagnostic, error) {
+	baseURIs, basedir, convert := rc.baseURIs, rc.basedir, rc.convert
+	original, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	message := getText(result.Message)
+	rule := sarif.ReportingDescriptor{}
+	ruleID := ""
+	if result.RuleID != nil {
+		ruleID = *result.RuleID
+	}
+	rule = rc.rules[ruleID]
+	level := ""
+	if result.Level != nil {
+		level = string(*result.Level)
+	} else if rule.DefaultConfiguration != nil && rule.DefaultConfiguration.Level != nil {
+		level = string(*rule.DefaultConfiguration.Level)
+	}
+	suggestionsMap := map[string][]*rdf.Suggestion{}
+	// Fixes are alternatives to each other. Use only the first one, which may
+	// change multiple artifacts.
+	if len(result.Fixes) > 0 {
+		for _, artifactChange := range result.Fixes[0].ArtifactChanges {
+			suggestions := []*rdf.Suggestion{}
+			path, err := getPath(artifactChange.ArtifactLocation, baseURIs, basedir)
+			if err != nil {
+				// invalid path
+				return nil, err
 			}
-			suggestionsMap := map[string][]*rdf.Suggestion{}
-			for _, fix := range result.Fixes {
-				for _, artifactChange := range fix.ArtifactChanges {
-					suggestions := []*rdf.Suggestion{}
-					path, err := getPath(artifactChange.ArtifactLocation, baseURIs, basedir)
-					if err != nil {
-						// invalid path
-						return nil, err
-					}
-					for _, replacement := range artifactChange.Replacements {
-						deletedRegion := replacement.DeletedRegion
-						rng := getRdfRange(deletedRegion)
-						if rng == nil || replacement.InsertedContent.Text == nil {
-							// No line information in fix
-							continue
-						}
-						s := &rdf.Suggestion{
-							Range: rng,
-							Text:  *replacement.InsertedContent.Text,
-						}
-						suggestions = append(suggestions, s)
-					}
-					suggestionsMap[path] = suggestions
+			for _, replacement := range artifactChange.Replacements {
+				deletedRegion := replacement.DeletedRegion
+				rng := getRdfRange(deletedRegion)
+				if rng == nil || replacement.InsertedContent.Text == nil {
+					// No line information in fix
+					continue
 				}
+				convert(path, rng)
+				s := &rdf.Suggestion{
+					Range: rng,
+					Text:  *replacement.InsertedContent.Text,
+				}
+				suggestions = append(suggestions, s)
 			}
+			suggestionsMap[path] = append(suggestionsMap[path], suggestions...)
+		}
+	}
 
-			relatedLocs := []*rdf.RelatedLocation{}
-			for _, relLoc := range result.RelatedLocations {
-				loc, err := toRDFormatLocation(relLoc, baseURIs, basedir)
+	relatedLocs := []*rdf.RelatedLocation{}
+	// Locations already in relatedLocs keyed by path and range.
+	seenLocs := make(map[string]bool)
+	for _, relLoc := range result.RelatedLocations {
+		loc, err := toRDFormatLocation(relLoc, baseURIs, basedir)
+		if err != nil {
+			return nil, err
+		}
+		convert(loc.GetPath(), loc.GetRange())
+		l := &rdf.RelatedLocation{
+			Location: loc,
+		}
+		if relLoc.Message != nil {
+			l.Message = getText(*relLoc.Message)
+		}
+		seenLocs[relatedLocationKey(loc)] = true
+		relatedLocs = append(relatedLocs, l)
+	}
+	// Steps of code flows (e.g. taint paths) in order.
+	for _, codeFlow := range result.CodeFlows {
+		for _, threadFlow := range codeFlow.ThreadFlows {
+			for _, tfLoc := range threadFlow.Locations {
+				if tfLoc.Location == nil {
+					continue
+				}
+				loc, err := toRDFormatLocation(*tfLoc.Location, baseURIs, basedir)
 				if err != nil {
 					return nil, err
 				}
+				convert(loc.GetPath(), loc.GetRange())
+				// Code flows often go through the related locations.
+				key := relatedLocationKey(loc)
+				if seenLocs[key] {
+					continue
+				}
+				seenLocs[key] = true
 				l := &rdf.RelatedLocation{
 					Location: loc,
 				}
-				if relLoc.Message != nil {
-					l.Message = getText(*relLoc.Message)
+				if tfLoc.Location.Message != nil {
+					l.Message = getText(*tfLoc.Location.Message)
 				}
 				relatedLocs = append(relatedLocs, l)
 			}
+		}
+	}
 
-			for _, location := range result.Locations {
-				var code *rdf.Code
-				if ruleID != "" {
-					code = &rdf.Code{
-						Value: ruleID,
-					}
-					if rule.HelpURI != nil {
-						code.Url = *rule.HelpURI
-					}
-				}
-				loc, err := toRDFormatLocation(location, baseURIs, basedir)
-				if err != nil {
-					return nil, err
-				}
-				d := &rdf.Diagnostic{
-					Message:  message,
-					Location: loc,
-					Severity: severity(level),
-					Source: &rdf.Source{
-						Name: name,
-						Url:  informationURI,
-					},
-					Code:             code,
-					Suggestions:      suggestionsMap[loc.GetPath()],
-					RelatedLocations: relatedLocs,
-					OriginalOutput:   string(original),
-				}
-				ds = append(ds, d)
+	var ds []*rdf.Diagnostic
+	for _, location := range result.Locations {
+		var code *rdf.Code
+		if ruleID != "" {
+			code = &rdf.Code{
+				Value: ruleID,
 			}
+			if rule.HelpURI != nil {
+				code.Url = *rule.HelpURI
+			}
+		}
+		loc, err := toRDFormatLocation(location, baseURIs, basedir)
+		if err != nil {
+			return nil, err
+		}
+		convert(loc.GetPath(), loc.GetRange())
+		d := &rdf.Diagnostic{
+			Message:  message,
+			Location: loc,
+			Severity: severity(level),
+			Source: &rdf.Source{
+				Name: rc.name,
+				Url:  rc.informationURI,
+			},
+			Code:             code,
+			Suggestions:      suggestionsMap[loc.GetPath()],
+			RelatedLocations: relatedLocs,
+			OriginalOutput:   string(original),
+			Fingerprints:     fingerprints(result),
 		}
+		ds = append(ds, d)
 	}
 	return ds, nil
 }
 
+// relatedLocationKey returns the key of the location to deduplicate related
+// locations.
+func relatedLocationKey(loc *rdf.Location) string {
+	rng := loc.GetRange()
+	return fmt.Sprintf("%s:%d:%d:%d:%d", loc.GetPath(),
+		rng.GetStart().GetLine(), rng.GetStart().GetColumn(),
+		rng.GetEnd().GetLine(), rng.GetEnd().GetColumn())
+}
+
+// sanitizeSarif removes results which cannot be unmarshaled from SARIF. It
+// returns the sanitized SARIF, errors of removed results and line numbers of
+// remaining results keyed by indices of the run and the result.
+func sanitizeSarif(b []byte) ([]byte, []*RecordError, map[[2]int]int, error) {
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return nil, nil, nil, err
+	}
+	var runs []map[string]json.RawMessage
+	if err := json.Unmarshal(doc["runs"], &runs); err != nil {
+		return nil, nil, nil, fmt.Errorf("runs: %w", err)
+	}
+	var errs []*RecordError
+	lines := make(map[[2]int]int)
+	for i, run := range runs {
+		var results []json.RawMessage
+		if err := json.Unmarshal(run["results"], &results); err != nil {
+			// Leave it to the parser as the whole run is invalid.
+			continue
+		}
+		valid := make([]json.RawMessage, 0, len(results))
+		for j, result := range results {
+			line := jsonPointerLine(b, []string{"runs", strconv.Itoa(i), "results", strconv.Itoa(j)})
+			if err := json.Unmarshal(result, new(sarif.Result)); err != nil {
+				errs = append(errs, &RecordError{Line: line, Err: fmt.Errorf("invalid result: %w", err)})
+				continue
+			}
+			lines[[2]int{i, len(valid)}] = line
+			valid = append(valid, result)
+		}
+		r, err := json.Marshal(valid)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		run["results"] = r
+	}
+	r, err := json.Marshal(runs)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	doc["runs"] = r
+	b, err = json.Marshal(doc)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return b, errs, lines, nil
+}
+
+// isSuppressed reports whether the result is suppressed. A suppression is in
+// effect unless its status is "underReview" or "rejected".
+func isSuppressed(result sarif.Result) bool {
+	for _, s := range result.Suppressions {
+		if s.Status == nil || *s.Status == sarif.Accepted {
+			return true
+		}
+	}
+	return false
+}
+
+// fingerprints merges fingerprints and partialFingerprints of the result.
+func fingerprints(result sarif.Result) map[string]string {
+	if len(result.Fingerprints) == 0 && len(result.PartialFingerprints) == 0 {
+		return nil
+	}
+	fps := make(map[string]string, len(result.Fingerprints)+len(result.PartialFingerprints))
+	for k, v := range result.PartialFingerprints {
+		fps[k] = v
+	}
+	for k, v := range result.Fingerprints {
+		fps[k] = v
+	}
+	return fps
+}
+
+// convertColumns converts columns of rng counted in Unicode code points (or
+// UTF-16 code units if utf16 is true) into UTF-8 bytes in place. Columns are
+// left as is if src is nil or they are out of range.
+func convertColumns(src []byte, rng *rdf.Range, utf16 bool) {
+	if src == nil {
+		return
+	}
+	for _, pos := range []*rdf.Position{rng.GetStart(), rng.GetEnd()} {
+		if pos.GetColumn() <= 0 {
+			continue
+		}
+		line, ok := rdf.SourceLine(src, int(pos.GetLine()))
+		if !ok {
+			continue
+		}
+		if col, err := byteColumn(line, int(pos.GetColumn()), utf16); err == nil {
+			pos.Column = int32(col)
+		}
+	}
+}
+
 func toRDFormatLocation(location sarif.Location,
 	baseURIs map[string]sarif.ArtifactLocation,
 	basedir string,
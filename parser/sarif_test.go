@@ -3,12 +3,16 @@ package parser
 import (
 	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/reviewdog/reviewdog/proto/rdf"
 	"github.com/reviewdog/reviewdog/service/serviceutil"
 	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/testing/protocmp"
 )
 
 func TestExampleSarifParser(t *testing.T) {
@@ -42,6 +46,169 @@ func TestExampleSarifParser(t *testing.T) {
 	}
 }
 
+func TestSarifParser_suppressionsAndBaselineState(t *testing.T) {
+	const sample = `{
+  "version": "2.1.0",
+  "runs": [{
+    "tool": {"driver": {"name": "codeql"}},
+    "results": [
+      {
+        "ruleId": "new",
+        "message": {"text": "new result"},
+        "baselineState": "new",
+        "partialFingerprints": {"primaryLocationLineHash": "aaa:1"},
+        "fingerprints": {"stable/v1": "bbb"},
+        "locations": [{"physicalLocation": {"artifactLocation": {"uri": "a.go"}, "region": {"startLine": 1}}}]
+      },
+      {
+        "ruleId": "unchanged",
+        "message": {"text": "unchanged result"},
+        "baselineState": "unchanged",
+        "locations": [{"physicalLocation": {"artifactLocation": {"uri": "a.go"}, "region": {"startLine": 2}}}]
+      },
+      {
+        "ruleId": "suppressed",
+        "message": {"text": "suppressed result"},
+        "suppressions": [{"kind": "inSource"}],
+        "locations": [{"physicalLocation": {"artifactLocation": {"uri": "a.go"}, "region": {"startLine": 3}}}]
+      },
+      {
+        "ruleId": "accepted",
+        "message": {"text": "accepted suppression"},
+        "suppressions": [{"kind": "external", "status": "accepted"}],
+        "locations": [{"physicalLocation": {"artifactLocation": {"uri": "a.go"}, "region": {"startLine": 4}}}]
+      },
+      {
+        "ruleId": "rejected",
+        "message": {"text": "rejected suppression"},
+        "suppressions": [{"kind": "external", "status": "rejected"}, {"kind": "inSource", "status": "underReview"}],
+        "locations": [{"physicalLocation": {"artifactLocation": {"uri": "a.go"}, "region": {"startLine": 5}}}]
+      }
+    ]
+  }]
+}`
+	tests := []struct {
+		skipUnchanged bool
+		want          []string
+	}{
+		{skipUnchanged: false, want: []string{"new", "unchanged", "rejected"}},
+		{skipUnchanged: true, want: []string{"new", "rejected"}},
+	}
+	for _, tt := range tests {
+		p := &SarifParser{SkipUnchanged: tt.skipUnchanged}
+		ds, err := p.Parse(strings.NewReader(sample))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, d := range ds {
+			got = append(got, d.GetCode().GetValue())
+		}
+		if diff := cmp.Diff(got, tt.want); diff != "" {
+			t.Errorf("SkipUnchanged=%v: (-got, +want):\n%s", tt.skipUnchanged, diff)
+		}
+		wantFps := map[string]string{"primaryLocationLineHash": "aaa:1", "stable/v1": "bbb"}
+		if diff := cmp.Diff(ds[0].GetFingerprints(), wantFps); diff != "" {
+			t.Errorf("fingerprints (-got, +want):\n%s", diff)
+		}
+		if fps := ds[1].GetFingerprints(); fps != nil {
+			t.Errorf("fingerprints = %v, want nil", fps)
+		}
+	}
+}
+
+func TestSarifParser_codeFlowsAndFixes(t *testing.T) {
+	const sample = `{
+  "version": "2.1.0",
+  "runs": [{
+    "tool": {"driver": {"name": "codeql"}},
+    "columnKind": "utf16CodeUnits",
+    "results": [{
+      "ruleId": "py/code-injection",
+      "message": {"text": "Code injection."},
+      "locations": [{"physicalLocation": {"artifactLocation": {"uri": "a.py"}, "region": {"startLine": 2, "startColumn": 6, "endColumn": 10}}}],
+      "relatedLocations": [{"physicalLocation": {"artifactLocation": {"uri": "a.py"}, "region": {"startLine": 1}}, "message": {"text": "user input"}}],
+      "codeFlows": [{
+        "threadFlows": [{
+          "locations": [
+            {"location": {"physicalLocation": {"artifactLocation": {"uri": "a.py"}, "region": {"startLine": 1}}, "message": {"text": "source"}}},
+            {"location": {"physicalLocation": {"artifactLocation": {"uri": "a.py"}, "region": {"startLine": 2, "startColumn": 6, "endColumn": 10}}, "message": {"text": "sink"}}}
+          ]
+        }]
+      }],
+      "fixes": [{
+        "artifactChanges": [
+          {
+            "artifactLocation": {"uri": "a.py"},
+            "replacements": [{"deletedRegion": {"startLine": 2, "startColumn": 6, "endColumn": 10}, "insertedContent": {"text": "safe"}}]
+          },
+          {
+            "artifactLocation": {"uri": "a.py"},
+            "replacements": [{"deletedRegion": {"startLine": 1, "startColumn": 1, "endColumn": 1}, "insertedContent": {"text": "import safe\n"}}]
+          }
+        ]
+      }, {
+        "description": {"text": "Alternative fix, which is ignored."},
+        "artifactChanges": [{
+          "artifactLocation": {"uri": "a.py"},
+          "replacements": [{"deletedRegion": {"startLine": 2, "startColumn": 6, "endColumn": 10}, "insertedContent": {"text": "literal_eval"}}]
+        }]
+      }]
+    }]
+  }]
+}`
+	p := &SarifParser{
+		readFile: func(path string) ([]byte, error) {
+			if filepath.Base(path) == "a.py" {
+				// "😀" is 2 UTF-16 code units and 4 bytes in UTF-8.
+				return []byte("x = input()\n😀 = eval(x)\n"), nil
+			}
+			return nil, os.ErrNotExist
+		},
+	}
+	got, err := p.Parse(strings.NewReader(sample))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("got %d diagnostics, want 1", len(got))
+	}
+	evalRange := &rdf.Range{
+		Start: &rdf.Position{Line: 2, Column: 8},
+		End:   &rdf.Position{Line: 2, Column: 12},
+	}
+	want := &rdf.Diagnostic{
+		Message:  "Code injection.",
+		Location: &rdf.Location{Path: "a.py", Range: evalRange},
+		Source:   &rdf.Source{Name: "codeql"},
+		Code:     &rdf.Code{Value: "py/code-injection"},
+		Suggestions: []*rdf.Suggestion{
+			{Range: evalRange, Text: "safe"},
+			{
+				Range: &rdf.Range{
+					Start: &rdf.Position{Line: 1, Column: 1},
+					End:   &rdf.Position{Line: 1, Column: 1},
+				},
+				Text: "import safe\n",
+			},
+		},
+		RelatedLocations: []*rdf.RelatedLocation{
+			{
+				// The "source" step of the code flow is deduplicated.
+				Message:  "user input",
+				Location: &rdf.Location{Path: "a.py", Range: &rdf.Range{Start: &rdf.Position{Line: 1}}},
+			},
+			{
+				Message:  "sink",
+				Location: &rdf.Location{Path: "a.py", Range: evalRange},
+			},
+		},
+	}
+	if diff := cmp.Diff(got[0], want, protocmp.Transform(), protocmp.IgnoreFields(&rdf.Diagnostic{}, "original_output")); diff != "" {
+		t.Errorf("(-got, +want):\n%s", diff)
+	}
+}
+
 func basedir() string {
 	root, err := serviceutil.GetGitRoot()
 	if err != nil {
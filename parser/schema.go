@@ -0,0 +1,149 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+
+	rdfschema "github.com/reviewdog/reviewdog/proto/rdf/jsonschema"
+)
+
+var (
+	schemaOnce             sync.Once
+	diagnosticSchema       *jsonschema.Schema
+	diagnosticResultSchema *jsonschema.Schema
+	schemaErr              error
+)
+
+// loadSchemas compiles JSON Schema of Diagnostic and DiagnosticResult in
+// proto/rdf/jsonschema.
+func loadSchemas() error {
+	schemaOnce.Do(func() {
+		c := jsonschema.NewCompiler()
+		for _, name := range []string{"Diagnostic.json", "DiagnosticResult.json"} {
+			f, err := rdfschema.FS.Open(name)
+			if err != nil {
+				schemaErr = err
+				return
+			}
+			doc, err := jsonschema.UnmarshalJSON(f)
+			f.Close()
+			if err != nil {
+				schemaErr = fmt.Errorf("failed to load %s: %w", name, err)
+				return
+			}
+			if err := c.AddResource(name, doc); err != nil {
+				schemaErr = err
+				return
+			}
+		}
+		if diagnosticSchema, schemaErr = c.Compile("Diagnostic.json"); schemaErr != nil {
+			return
+		}
+		diagnosticResultSchema, schemaErr = c.Compile("DiagnosticResult.json")
+	})
+	return schemaErr
+}
+
+// validateSchema validates JSON data against the schema. Each returned error
+// is *RecordError which points to the invalid value in data. Line numbers are
+// offset by baseLine, which is the line number where data starts.
+func validateSchema(sch *jsonschema.Schema, data []byte, baseLine int) []*RecordError {
+	inst, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+	if err != nil {
+		return []*RecordError{{Line: baseLine, Err: err}}
+	}
+	err = sch.Validate(inst)
+	var verr *jsonschema.ValidationError
+	if !errors.As(err, &verr) {
+		if err != nil {
+			return []*RecordError{{Line: baseLine, Err: err}}
+		}
+		return nil
+	}
+	var errs []*RecordError
+	for _, leaf := range leafValidationErrors(verr) {
+		line := baseLine
+		if l := jsonPointerLine(data, leaf.InstanceLocation); l > 0 {
+			line += l - 1
+		}
+		// leaf.Error() looks like "at '/location/range': got string, want object".
+		errs = append(errs, &RecordError{Line: line, Err: errors.New(leaf.Error())})
+	}
+	return errs
+}
+
+func leafValidationErrors(err *jsonschema.ValidationError) []*jsonschema.ValidationError {
+	if len(err.Causes) == 0 {
+		return []*jsonschema.ValidationError{err}
+	}
+	var leaves []*jsonschema.ValidationError
+	for _, cause := range err.Causes {
+		leaves = append(leaves, leafValidationErrors(cause)...)
+	}
+	return leaves
+}
+
+// jsonPointerLine returns 1-based line number of the value pointed by the
+// JSON Pointer tokens in data. It returns 0 if the value is not found.
+func jsonPointerLine(data []byte, ptr []string) int {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	offset, ok := findJSONValue(dec, data, ptr)
+	if !ok {
+		return 0
+	}
+	return bytes.Count(data[:offset], []byte{'\n'}) + 1
+}
+
+// findJSONValue returns byte offset of the value pointed by ptr, reading the
+// next value from dec.
+func findJSONValue(dec *json.Decoder, data []byte, ptr []string) (int, bool) {
+	offset := int(dec.InputOffset())
+	// Skip whitespaces and separators before the value.
+	for offset < len(data) && bytes.IndexByte([]byte(" \t\r\n,:"), data[offset]) >= 0 {
+		offset++
+	}
+	if len(ptr) == 0 {
+		return offset, true
+	}
+	tok, err := dec.Token()
+	if err != nil {
+		return 0, false
+	}
+	switch tok {
+	case json.Delim('{'):
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return 0, false
+			}
+			if key == ptr[0] {
+				return findJSONValue(dec, data, ptr[1:])
+			}
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return 0, false
+			}
+		}
+	case json.Delim('['):
+		idx, err := strconv.Atoi(ptr[0])
+		if err != nil {
+			return 0, false
+		}
+		for i := 0; dec.More(); i++ {
+			if i == idx {
+				return findJSONValue(dec, data, ptr[1:])
+			}
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return 0, false
+			}
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,110 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/reviewdog/reviewdog/proto/rdf"
+)
+
+var _ Parser = &ShellCheckParser{}
+
+// ShellCheckParser is parser for ShellCheck json1 format (shellcheck -f json1).
+type ShellCheckParser struct {
+	// readFile reads source file content. Replaceable for testing.
+	readFile func(path string) ([]byte, error)
+}
+
+// NewShellCheckParser returns a new ShellCheckParser.
+func NewShellCheckParser() *ShellCheckParser {
+	return &ShellCheckParser{readFile: os.ReadFile}
+}
+
+// ShellCheckResult represents ShellCheck json1 output.
+//
+// References:
+//   - https://github.com/koalaman/shellcheck/blob/master/shellcheck.1.md#formats
+type ShellCheckResult struct {
+	Comments []*ShellCheckComment `json:"comments"`
+}
+
+// ShellCheckComment represents a comment of ShellCheck.
+type ShellCheckComment struct {
+	File      string         `json:"file"`
+	Line      int            `json:"line"`
+	EndLine   int            `json:"endLine"`
+	Column    int            `json:"column"`
+	EndColumn int            `json:"endColumn"`
+	Level     string         `json:"level"` // error, warning, info or style.
+	Code      int            `json:"code"`
+	Message   string         `json:"message"`
+	Fix       *ShellCheckFix `json:"fix"`
+}
+
+// ShellCheckFix represents a fix of ShellCheck.
+type ShellCheckFix struct {
+	Replacements []*ShellCheckReplacement `json:"replacements"`
+}
+
+// ShellCheckReplacement represents a replacement of ShellCheck fix.
+type ShellCheckReplacement struct {
+	Line        int    `json:"line"`
+	EndLine     int    `json:"endLine"`
+	Column      int    `json:"column"`
+	EndColumn   int    `json:"endColumn"`
+	Replacement string `json:"replacement"`
+}
+
+func (p *ShellCheckParser) Parse(r io.Reader) ([]*rdf.Diagnostic, error) {
+	var result ShellCheckResult
+	if err := json.NewDecoder(r).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal ShellCheck json1: %w", err)
+	}
+	srcs := newSourceCache(p.readFile)
+	var ds []*rdf.Diagnostic
+	for _, c := range result.Comments {
+		code := fmt.Sprintf("SC%d", c.Code)
+		d := &rdf.Diagnostic{
+			Message: c.Message,
+			Location: &rdf.Location{
+				Path: c.File,
+				Range: &rdf.Range{
+					Start: &rdf.Position{Line: int32(c.Line), Column: int32(c.Column)},
+					End:   &rdf.Position{Line: int32(c.EndLine), Column: int32(c.EndColumn)},
+				},
+			},
+			Severity: shellcheckSeverity(c.Level),
+			Source:   &rdf.Source{Name: "shellcheck", Url: "https://www.shellcheck.net/"},
+			Code:     &rdf.Code{Value: code, Url: "https://www.shellcheck.net/wiki/" + code},
+			OriginalOutput: fmt.Sprintf("%s:%d:%d: %s: %s [%s]",
+				c.File, c.Line, c.Column, c.Level, c.Message, code),
+		}
+		// ShellCheck counts columns in characters. Convert them with the same
+		// source as suggestions.
+		convertColumns(srcs.get(c.File), d.GetLocation().GetRange(), false)
+		if c.Fix != nil && len(c.Fix.Replacements) > 0 {
+			edits := make([]textEdit, 0, len(c.Fix.Replacements))
+			for _, rep := range c.Fix.Replacements {
+				edits = append(edits, textEdit{
+					startLine: rep.Line, startColumn: rep.Column,
+					endLine: rep.EndLine, endColumn: rep.EndColumn,
+					text: rep.Replacement,
+				})
+			}
+			if s := buildFixSuggestion(srcs.get(c.File), edits); s != nil {
+				d.Suggestions = []*rdf.Suggestion{s}
+			}
+		}
+		ds = append(ds, d)
+	}
+	return ds, nil
+}
+
+func shellcheckSeverity(level string) rdf.Severity {
+	if level == "style" {
+		return rdf.Severity_INFO
+	}
+	return severity(level)
+}
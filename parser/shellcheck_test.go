@@ -0,0 +1,88 @@
+package parser
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/reviewdog/reviewdog/proto/rdf"
+)
+
+func TestShellCheckParser(t *testing.T) {
+	const sample = `{"comments":[
+{"file":"a.sh","line":2,"endLine":2,"column":10,"endColumn":14,"level":"info","code":2086,"message":"Double quote to prevent globbing and word splitting.","fix":{"replacements":[{"column":10,"endColumn":10,"endLine":2,"insertionPoint":"afterEnd","line":2,"precedence":7,"replacement":"\""},{"column":14,"endColumn":14,"endLine":2,"insertionPoint":"beforeStart","line":2,"precedence":7,"replacement":"\""}]}},
+{"file":"a.sh","line":1,"endLine":1,"column":1,"endColumn":4,"level":"style","code":2034,"message":"foo appears unused.","fix":null},
+{"file":"missing.sh","line":1,"endLine":1,"column":6,"endColumn":10,"level":"error","code":2086,"message":"Double quote to prevent globbing and word splitting.","fix":{"replacements":[{"column":6,"endColumn":6,"endLine":1,"line":1,"replacement":"\""},{"column":10,"endColumn":10,"endLine":1,"line":1,"replacement":"\""}]}}
+]}`
+	p := NewShellCheckParser()
+	p.readFile = func(path string) ([]byte, error) {
+		if path == "a.sh" {
+			return []byte("foo=1\necho \"é\" $foo\n"), nil
+		}
+		return nil, errors.New("not found")
+	}
+	got, err := p.Parse(strings.NewReader(sample))
+	if err != nil {
+		t.Fatal(err)
+	}
+	shellcheck := &rdf.Source{Name: "shellcheck", Url: "https://www.shellcheck.net/"}
+	want := []*rdf.Diagnostic{
+		{
+			Message: "Double quote to prevent globbing and word splitting.",
+			Location: &rdf.Location{
+				Path: "a.sh",
+				// "é" is 2 bytes in UTF-8.
+				Range: &rdf.Range{
+					Start: &rdf.Position{Line: 2, Column: 11},
+					End:   &rdf.Position{Line: 2, Column: 15},
+				},
+			},
+			Severity: rdf.Severity_INFO,
+			Source:   shellcheck,
+			Code:     &rdf.Code{Value: "SC2086", Url: "https://www.shellcheck.net/wiki/SC2086"},
+			Suggestions: []*rdf.Suggestion{{
+				Range: &rdf.Range{
+					Start: &rdf.Position{Line: 2, Column: 11},
+					End:   &rdf.Position{Line: 2, Column: 15},
+				},
+				Text: `"$foo"`,
+			}},
+			OriginalOutput: "a.sh:2:10: info: Double quote to prevent globbing and word splitting. [SC2086]",
+		},
+		{
+			Message: "foo appears unused.",
+			Location: &rdf.Location{
+				Path: "a.sh",
+				Range: &rdf.Range{
+					Start: &rdf.Position{Line: 1, Column: 1},
+					End:   &rdf.Position{Line: 1, Column: 4},
+				},
+			},
+			Severity:       rdf.Severity_INFO,
+			Source:         shellcheck,
+			Code:           &rdf.Code{Value: "SC2034", Url: "https://www.shellcheck.net/wiki/SC2034"},
+			OriginalOutput: "a.sh:1:1: style: foo appears unused. [SC2034]",
+		},
+		{
+			// Multiple edits cannot be merged without source.
+			Message: "Double quote to prevent globbing and word splitting.",
+			Location: &rdf.Location{
+				Path: "missing.sh",
+				Range: &rdf.Range{
+					Start: &rdf.Position{Line: 1, Column: 6},
+					End:   &rdf.Position{Line: 1, Column: 10},
+				},
+			},
+			Severity:       rdf.Severity_ERROR,
+			Source:         shellcheck,
+			Code:           &rdf.Code{Value: "SC2086", Url: "https://www.shellcheck.net/wiki/SC2086"},
+			OriginalOutput: "missing.sh:1:6: error: Double quote to prevent globbing and word splitting. [SC2086]",
+		},
+	}
+	if diff := cmp.Diff(got, want, protocmp.Transform()); diff != "" {
+		t.Errorf("diff (-got +want):\n%s", diff)
+	}
+}
@@ -0,0 +1,129 @@
+package parser
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/reviewdog/reviewdog/proto/rdf"
+)
+
+var _ Parser = &TeamCityInspectionParser{}
+
+// TeamCityInspectionParser is parser for TeamCity inspection service messages
+// (e.g. `##teamcity[inspection typeId='semi' message='msg' file='app.js' line='1' SEVERITY='ERROR']`).
+// Descriptions of inspectionType messages are used as messages of
+// inspections which have no message. Other lines are ignored.
+//
+// References:
+//   - https://www.jetbrains.com/help/teamcity/service-messages.html#Reporting+Inspections
+type TeamCityInspectionParser struct{}
+
+// NewTeamCityInspectionParser returns a new TeamCityInspectionParser.
+func NewTeamCityInspectionParser() *TeamCityInspectionParser {
+	return &TeamCityInspectionParser{}
+}
+
+var (
+	teamcityMessageRe   = regexp.MustCompile(`##teamcity\[(inspectionType|inspection)\s(.*)\]\s*$`)
+	teamcityAttributeRe = regexp.MustCompile(`([\w.-]+)='((?:[^'|]|\|.)*)'`)
+)
+
+func (p *TeamCityInspectionParser) Parse(r io.Reader) ([]*rdf.Diagnostic, error) {
+	var ds []*rdf.Diagnostic
+	descriptions := map[string]string{}
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		m := teamcityMessageRe.FindStringSubmatch(s.Text())
+		if m == nil {
+			continue
+		}
+		attrs := map[string]string{}
+		for _, a := range teamcityAttributeRe.FindAllStringSubmatch(m[2], -1) {
+			attrs[a[1]] = teamcityUnescape(a[2])
+		}
+		if m[1] == "inspectionType" {
+			descriptions[attrs["id"]] = attrs["description"]
+			continue
+		}
+		typeID := attrs["typeId"]
+		message := attrs["message"]
+		if message == "" {
+			message = descriptions[typeID]
+		}
+		d := &rdf.Diagnostic{
+			Message:        message,
+			Severity:       teamcitySeverity(attrs["SEVERITY"]),
+			OriginalOutput: s.Text(),
+		}
+		if path := attrs["file"]; path != "" {
+			d.Location = &rdf.Location{
+				Path:  path,
+				Range: annotationRange(atoi(attrs["line"]), 0, 0, 0),
+			}
+		}
+		if typeID != "" {
+			d.Code = &rdf.Code{Value: typeID}
+		}
+		ds = append(ds, d)
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return ds, nil
+}
+
+func teamcitySeverity(s string) rdf.Severity {
+	switch s {
+	case "ERROR":
+		return rdf.Severity_ERROR
+	case "WARNING":
+		return rdf.Severity_WARNING
+	case "WEAK WARNING", "INFO":
+		return rdf.Severity_INFO
+	default:
+		return rdf.Severity_UNKNOWN_SEVERITY
+	}
+}
+
+// teamcityUnescape unescapes a value of TeamCity service messages.
+func teamcityUnescape(s string) string {
+	if !strings.Contains(s, "|") {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '|' || i+1 >= len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 'x':
+			b.WriteString("\u0085") // next line
+		case 'l':
+			b.WriteString("\u2028") // line separator
+		case 'p':
+			b.WriteString("\u2029") // paragraph separator
+		case '0':
+			// |0xNNNN: unicode symbol.
+			if i+5 < len(s) && s[i+1] == 'x' {
+				if r, err := strconv.ParseUint(s[i+2:i+6], 16, 32); err == nil {
+					b.WriteRune(rune(r))
+					i += 5
+					continue
+				}
+			}
+			b.WriteString("|0")
+		default: // ', |, [ and ]
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,48 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/reviewdog/reviewdog/proto/rdf"
+)
+
+func TestTeamCityInspectionParser(t *testing.T) {
+	const sample = `##teamcity[inspectionType id='unused' name='Unused' category='Style' description='Unused declaration.']
+##teamcity[inspection typeId='semi' message='Missing |'semicolon|'.|n|[x|] |0x263A' file='src/app.js' line='10' SEVERITY='ERROR']
+##teamcity[inspection typeId='unused' file='src/b.js' line='3' SEVERITY='WEAK WARNING']
+##teamcity[testStarted name='test']
+`
+	got, err := NewTeamCityInspectionParser().Parse(strings.NewReader(sample))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*rdf.Diagnostic{
+		{
+			Message: "Missing 'semicolon'.\n[x] ☺",
+			Location: &rdf.Location{
+				Path:  "src/app.js",
+				Range: &rdf.Range{Start: &rdf.Position{Line: 10}},
+			},
+			Severity:       rdf.Severity_ERROR,
+			Code:           &rdf.Code{Value: "semi"},
+			OriginalOutput: "##teamcity[inspection typeId='semi' message='Missing |'semicolon|'.|n|[x|] |0x263A' file='src/app.js' line='10' SEVERITY='ERROR']",
+		},
+		{
+			Message: "Unused declaration.",
+			Location: &rdf.Location{
+				Path:  "src/b.js",
+				Range: &rdf.Range{Start: &rdf.Position{Line: 3}},
+			},
+			Severity:       rdf.Severity_INFO,
+			Code:           &rdf.Code{Value: "unused"},
+			OriginalOutput: "##teamcity[inspection typeId='unused' file='src/b.js' line='3' SEVERITY='WEAK WARNING']",
+		},
+	}
+	if diff := cmp.Diff(got, want, protocmp.Transform()); diff != "" {
+		t.Errorf("diff (-got +want):\n%s", diff)
+	}
+}
@@ -0,0 +1,100 @@
+package parser
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/reviewdog/reviewdog/proto/rdf"
+)
+
+var _ Parser = &WorkflowCommandParser{}
+
+// WorkflowCommandParser is parser for GitHub Actions workflow commands
+// (e.g. `::error file=app.js,line=1,col=5::Missing semicolon`). Other lines
+// are ignored.
+//
+// References:
+//   - https://docs.github.com/en/actions/using-workflows/workflow-commands-for-github-actions
+type WorkflowCommandParser struct{}
+
+// NewWorkflowCommandParser returns a new WorkflowCommandParser.
+func NewWorkflowCommandParser() *WorkflowCommandParser {
+	return &WorkflowCommandParser{}
+}
+
+var workflowCommandRe = regexp.MustCompile(`(?:^|\s)::(error|warning|notice)(?:\s+([^:]*))?::(.*)$`)
+
+var (
+	workflowCommandDataUnescaper     = strings.NewReplacer("%25", "%", "%0D", "\r", "%0A", "\n")
+	workflowCommandPropertyUnescaper = strings.NewReplacer("%25", "%", "%0D", "\r", "%0A", "\n", "%3A", ":", "%2C", ",")
+)
+
+func (p *WorkflowCommandParser) Parse(r io.Reader) ([]*rdf.Diagnostic, error) {
+	var ds []*rdf.Diagnostic
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		m := workflowCommandRe.FindStringSubmatch(s.Text())
+		if m == nil {
+			continue
+		}
+		props := map[string]string{}
+		for _, kv := range strings.Split(m[2], ",") {
+			if k, v, ok := strings.Cut(kv, "="); ok {
+				props[strings.TrimSpace(k)] = workflowCommandPropertyUnescaper.Replace(v)
+			}
+		}
+		message := workflowCommandDataUnescaper.Replace(m[3])
+		if title := props["title"]; title != "" {
+			message = title + ": " + message
+		}
+		d := &rdf.Diagnostic{
+			Message:        message,
+			Severity:       workflowCommandSeverity(m[1]),
+			OriginalOutput: s.Text(),
+		}
+		if path := props["file"]; path != "" {
+			d.Location = &rdf.Location{
+				Path: path,
+				Range: annotationRange(atoi(props["line"]), atoi(props["col"]),
+					atoi(props["endLine"]), atoi(props["endColumn"])),
+			}
+		}
+		ds = append(ds, d)
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return ds, nil
+}
+
+func workflowCommandSeverity(command string) rdf.Severity {
+	if command == "notice" {
+		return rdf.Severity_INFO
+	}
+	return severity(command)
+}
+
+// annotationRange returns a range from positions of CI annotations. Zero means
+// absent.
+func annotationRange(line, col, endLine, endCol int) *rdf.Range {
+	if line == 0 {
+		return nil
+	}
+	rng := &rdf.Range{Start: &rdf.Position{Line: int32(line), Column: int32(col)}}
+	if endLine == 0 && endCol > 0 {
+		endLine = line
+	}
+	if endLine > 0 {
+		rng.End = &rdf.Position{Line: int32(endLine), Column: int32(endCol)}
+	}
+	return rng
+}
+
+// atoi converts s to int. It returns 0 if s is not a number.
+func atoi(s string) int {
+	n, _ := strconv.Atoi(strings.TrimSpace(s))
+	return n
+}
@@ -0,0 +1,58 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/reviewdog/reviewdog/proto/rdf"
+)
+
+func TestWorkflowCommandParser(t *testing.T) {
+	const sample = `Run npm test
+::error file=src/app.js,line=10,col=5,endColumn=8,title=Lint%3A semi::Missing semicolon.%0Anext line 100%25
+::warning file=src/a%2Cb.js,line=3,endLine=5::Long function.
+2024-01-01T00:00:00.0000000Z ::notice::Done.
+::debug::debug message
+`
+	got, err := NewWorkflowCommandParser().Parse(strings.NewReader(sample))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*rdf.Diagnostic{
+		{
+			Message: "Lint: semi: Missing semicolon.\nnext line 100%",
+			Location: &rdf.Location{
+				Path: "src/app.js",
+				Range: &rdf.Range{
+					Start: &rdf.Position{Line: 10, Column: 5},
+					End:   &rdf.Position{Line: 10, Column: 8},
+				},
+			},
+			Severity:       rdf.Severity_ERROR,
+			OriginalOutput: "::error file=src/app.js,line=10,col=5,endColumn=8,title=Lint%3A semi::Missing semicolon.%0Anext line 100%25",
+		},
+		{
+			Message: "Long function.",
+			Location: &rdf.Location{
+				Path: "src/a,b.js",
+				Range: &rdf.Range{
+					Start: &rdf.Position{Line: 3},
+					End:   &rdf.Position{Line: 5},
+				},
+			},
+			Severity:       rdf.Severity_WARNING,
+			OriginalOutput: "::warning file=src/a%2Cb.js,line=3,endLine=5::Long function.",
+		},
+		{
+			Message:        "Done.",
+			Severity:       rdf.Severity_INFO,
+			OriginalOutput: "2024-01-01T00:00:00.0000000Z ::notice::Done.",
+		},
+	}
+	if diff := cmp.Diff(got, want, protocmp.Transform()); diff != "" {
+		t.Errorf("diff (-got +want):\n%s", diff)
+	}
+}
@@ -1,7 +1,10 @@
 package pathutil
 
 import (
+	"fmt"
+	"net/url"
 	"path/filepath"
+	"regexp"
 	"strings"
 
 	"github.com/reviewdog/reviewdog/proto/rdf"
@@ -10,6 +13,11 @@ import (
 // NormalizePath return normalized path with workdir and relative path to
 // project.
 func NormalizePath(path, workdir, projectRelPath string) string {
+	if strings.HasPrefix(path, "file:") {
+		if p, err := URIToPath(path); err == nil {
+			path = p
+		}
+	}
 	path = filepath.Clean(path)
 	if path == "." {
 		return ""
@@ -28,6 +36,44 @@ func NormalizePath(path, workdir, projectRelPath string) string {
 	return filepath.ToSlash(path)
 }
 
+var (
+	windowsDriveRe = regexp.MustCompile(`^/[A-Za-z]:`)
+	// uriSchemeRe matches URI schemes except for single letters, which are
+	// Windows drive letters.
+	uriSchemeRe = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9+.-]+:`)
+)
+
+// URIToPath converts file URI (e.g. file:///home/user/a%20b.go) to file path.
+// It returns uri as is if it's not URI.
+func URIToPath(uri string) (string, error) {
+	if !uriSchemeRe.MatchString(uri) {
+		return uri, nil
+	}
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", err
+	}
+	if u.Scheme != "file" {
+		return "", fmt.Errorf("unsupported URI scheme: %q", uri)
+	}
+	path := u.Path
+	if u.Opaque != "" {
+		// e.g. file:a.go
+		if path, err = url.PathUnescape(u.Opaque); err != nil {
+			return "", err
+		}
+	}
+	switch {
+	case u.Host != "" && u.Host != "localhost":
+		// UNC path. e.g. file://server/share/a.go
+		path = "//" + u.Host + path
+	case windowsDriveRe.MatchString(path):
+		// e.g. file:///C:/a.go
+		path = path[1:]
+	}
+	return filepath.FromSlash(path), nil
+}
+
 // NormalizeDiffPath return path normalized path from given path in diff with
 // strip.
 func NormalizeDiffPath(diffpath string, strip int) string {
@@ -56,3 +56,37 @@ func TestNormalizePathInResults(t *testing.T) {
 		}
 	}
 }
+
+func TestURIToPath(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "file:///home/user/a%20b.go", want: "/home/user/a b.go"},
+		{in: "file://localhost/home/user/a.go", want: "/home/user/a.go"},
+		{in: "file:///C:/project/a.go", want: "C:/project/a.go"},
+		{in: "file:///c%3A/project/a.go", want: "c:/project/a.go"},
+		{in: "file://server/share/a.go", want: "//server/share/a.go"},
+		{in: "a/b.go", want: "a/b.go"},
+		{in: "/abs/b.go", want: "/abs/b.go"},
+		{in: `C:\project\a.go`, want: `C:\project\a.go`},
+		{in: "untitled:Untitled-1", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := URIToPath(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("URIToPath(%q): want error, got %q", tt.in, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("URIToPath(%q): unexpected error: %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("URIToPath(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -2,11 +2,22 @@
 // config.
 package project
 
-import "gopkg.in/yaml.v3"
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/reviewdog/reviewdog/parser"
+)
 
 // Config represents reviewdog config.
 type Config struct {
 	Runner map[string]*Runner
+	// User-defined named errorformats which can be used as `format` of
+	// runners and -f flag.
+	Formats []*parser.Format
+	// Rules to normalize `source` attributes of checkstyle input.
+	CheckStyleSources []*parser.CheckStyleSource `yaml:"checkstyle_sources"`
 }
 
 // Runner represents config for a runner.
@@ -17,10 +28,19 @@ type Runner struct {
 	Name string
 	// errorformat name. (e.g. `checkstyle`)
 	Format string
+	// errorformat names for mixed output of multiple tools. (e.g. `[golint, tsc]`)
+	Formats []string
 	// errorformat. (e.g. `%f:%l:%c:%m`, `%-G%.%#`)
 	Errorformat []string
 	// Report Level for this runner. ("info", "warning", "error")
 	Level string
+	// How to handle invalid records in input. ("off", "warning", "diagnostic")
+	Lenient string
+	// Unit of columns in input. ("byte", "utf16", "codepoint")
+	ColumnUnit string `yaml:"column_unit"`
+	// Run the command as a formatter and report changes in working tree as
+	// suggestions. The working tree is restored after running the command.
+	Formatter bool
 }
 
 // Parse parses reviewdog config in yaml format.
@@ -35,5 +55,13 @@ func Parse(yml []byte) (*Config, error) {
 			runner.Name = name
 		}
 	}
+	for i, f := range out.Formats {
+		if f.Name == "" {
+			return nil, fmt.Errorf("formats[%d]: name is empty", i)
+		}
+		if len(f.Errorformat) == 0 {
+			return nil, fmt.Errorf("formats[%s]: errorformat is empty", f.Name)
+		}
+	}
 	return out, nil
 }
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/kylelemons/godebug/pretty"
+
+	"github.com/reviewdog/reviewdog/parser"
 )
 
 func TestParse(t *testing.T) {
@@ -25,6 +27,28 @@ runner:
     name: nameoverwritten
     format: checkstyle
     level: error
+  mylinter:
+    cmd: mylinter .
+    format: mylint
+  lint:
+    cmd: make lint
+    formats: [golint, tsc]
+    column_unit: utf16
+  gofmt:
+    cmd: gofmt -w .
+    formatter: true
+
+formats:
+  - name: mylint
+    description: My linter
+    errorformat:
+      - "%f:%l: %m"
+    severity: warning
+
+checkstyle_sources:
+  - name: mytool
+    pattern: '^mytool\.(\w+)$'
+    url: https://example.com/rules/{{.rule}}
 `
 
 	want := &Config{
@@ -47,6 +71,37 @@ runner:
 				Name:   "nameoverwritten",
 				Level:  "error",
 			},
+			"mylinter": {
+				Cmd:    "mylinter .",
+				Format: "mylint",
+				Name:   "mylinter",
+			},
+			"lint": {
+				Cmd:        "make lint",
+				Formats:    []string{"golint", "tsc"},
+				ColumnUnit: "utf16",
+				Name:       "lint",
+			},
+			"gofmt": {
+				Cmd:       "gofmt -w .",
+				Formatter: true,
+				Name:      "gofmt",
+			},
+		},
+		Formats: []*parser.Format{
+			{
+				Name:        "mylint",
+				Description: "My linter",
+				Errorformat: []string{`%f:%l: %m`},
+				Severity:    "warning",
+			},
+		},
+		CheckStyleSources: []*parser.CheckStyleSource{
+			{
+				Name:    "mytool",
+				Pattern: `^mytool\.(\w+)$`,
+				URL:     "https://example.com/rules/{{.rule}}",
+			},
 		},
 	}
 
@@ -59,3 +114,14 @@ runner:
 	}
 
 }
+
+func TestParse_invalidFormats(t *testing.T) {
+	for _, yml := range []string{
+		"formats:\n  - errorformat: ['%f:%l: %m']\n",
+		"formats:\n  - name: mylint\n",
+	} {
+		if _, err := Parse([]byte(yml)); err == nil {
+			t.Errorf("Parse(%q): want error, got nil", yml)
+		}
+	}
+}
@@ -0,0 +1,291 @@
+package project
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+
+	"github.com/reviewdog/reviewdog/diff"
+	"github.com/reviewdog/reviewdog/parser"
+	"github.com/reviewdog/reviewdog/proto/rdf"
+)
+
+// fileSnapshot represents the content of a file in working tree.
+type fileSnapshot struct {
+	content []byte
+	mode    fs.FileMode
+}
+
+// snapshot represents contents of files in working tree before running a
+// formatter.
+type snapshot struct {
+	// git is true if the current directory is in a git repository. Then files
+	// has only modified and untracked files, and contents of other files are
+	// read from the git index on demand.
+	git bool
+	// files are contents of files keyed by a slash separated path relative to
+	// the current directory. nil means the file doesn't exist.
+	files map[string]*fileSnapshot
+}
+
+// fileChange represents a file changed after taking the snapshot.
+type fileChange struct {
+	path string
+	// base is the content before the change. nil if the file is created.
+	base *fileSnapshot
+	// content is the current content. nil if the file is deleted.
+	content []byte
+}
+
+// takeSnapshot takes a snapshot of files in the current directory. It
+// snapshots modified and untracked files which are not ignored by git if the
+// current directory is in a git repository, or all files otherwise.
+func takeSnapshot(ctx context.Context) (*snapshot, error) {
+	s := &snapshot{files: make(map[string]*fileSnapshot)}
+	paths, err := gitLsFiles(ctx, "--modified", "--others", "--exclude-standard")
+	if err == nil {
+		s.git = true
+	} else if paths, err = walkFiles(); err != nil {
+		return nil, err
+	}
+	for _, path := range paths {
+		f, err := readFileSnapshot(path)
+		if err != nil {
+			return nil, err
+		}
+		if f == nil && !s.git {
+			continue
+		}
+		// Files deleted in working tree are recorded as nil in git mode.
+		s.files[filepath.ToSlash(path)] = f
+	}
+	return s, nil
+}
+
+// readFileSnapshot returns the content of the regular file. It returns nil if
+// the file doesn't exist or it's not a regular file.
+func readFileSnapshot(path string) (*fileSnapshot, error) {
+	fi, err := os.Lstat(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if !fi.Mode().IsRegular() {
+		return nil, nil
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return &fileSnapshot{content: content, mode: fi.Mode().Perm()}, nil
+}
+
+func gitLsFiles(ctx context.Context, args ...string) ([]string, error) {
+	b, err := exec.CommandContext(ctx, "git", append([]string{"ls-files", "-z"}, args...)...).Output()
+	if err != nil {
+		return nil, err
+	}
+	if len(b) == 0 {
+		return nil, nil
+	}
+	return strings.Split(strings.TrimSuffix(string(b), "\x00"), "\x00"), nil
+}
+
+// walkFiles returns all regular files in the current directory.
+func walkFiles() ([]string, error) {
+	var paths []string
+	err := filepath.WalkDir(".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		if d.Type().IsRegular() {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	return paths, err
+}
+
+// changes returns files changed after taking the snapshot in order of path.
+func (s *snapshot) changes(ctx context.Context) ([]*fileChange, error) {
+	// Files which may be changed. Value is true if the file is tracked by git
+	// and its content before the change should be read from the git index.
+	candidates := make(map[string]bool, len(s.files))
+	add := func(paths []string, tracked bool) {
+		for _, path := range paths {
+			path = filepath.ToSlash(path)
+			if _, ok := candidates[path]; !ok {
+				candidates[path] = tracked
+			}
+		}
+	}
+	for path := range s.files {
+		candidates[path] = false
+	}
+	if s.git {
+		modified, err := gitLsFiles(ctx, "--modified")
+		if err != nil {
+			return nil, err
+		}
+		add(modified, true)
+		others, err := gitLsFiles(ctx, "--others", "--exclude-standard")
+		if err != nil {
+			return nil, err
+		}
+		add(others, false)
+	} else {
+		paths, err := walkFiles()
+		if err != nil {
+			return nil, err
+		}
+		add(paths, false)
+	}
+
+	var changes []*fileChange
+	for _, path := range sortedKeys(candidates) {
+		cur, err := readFileSnapshot(filepath.FromSlash(path))
+		if err != nil {
+			return nil, err
+		}
+		c := &fileChange{path: path}
+		if cur != nil {
+			c.content = cur.content
+		}
+		if base, ok := s.files[path]; ok {
+			c.base = base
+		} else if candidates[path] {
+			if c.base, err = gitIndexFile(ctx, path, cur); err != nil {
+				return nil, err
+			}
+		}
+		if c.base == nil && cur == nil {
+			continue
+		}
+		if c.base != nil && cur != nil && bytes.Equal(c.base.content, cur.content) {
+			continue
+		}
+		changes = append(changes, c)
+	}
+	return changes, nil
+}
+
+// gitIndexFile returns the content of the file in the git index. Mode of cur
+// is used if any as the index may not match the file system.
+func gitIndexFile(ctx context.Context, path string, cur *fileSnapshot) (*fileSnapshot, error) {
+	content, err := exec.CommandContext(ctx, "git", "show", ":./"+path).Output()
+	if err != nil {
+		return nil, fmt.Errorf("fail to read %s from git index: %w", path, err)
+	}
+	f := &fileSnapshot{content: content, mode: 0o644}
+	if cur != nil {
+		f.mode = cur.mode
+	}
+	return f, nil
+}
+
+// diff returns unified diff from the snapshot to the current working tree.
+// Files which are created after taking the snapshot are ignored.
+func (s *snapshot) diff(ctx context.Context) ([]byte, error) {
+	changes, err := s.changes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	for _, c := range changes {
+		if c.base == nil {
+			continue
+		}
+		buf.Write(diff.Unified("a/"+c.path, "b/"+c.path, c.base.content, c.content))
+	}
+	return buf.Bytes(), nil
+}
+
+// restore restores changed files in working tree to the snapshot and removes
+// files created after taking the snapshot.
+func (s *snapshot) restore(ctx context.Context) error {
+	changes, err := s.changes(ctx)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, c := range changes {
+		p := filepath.FromSlash(c.path)
+		if c.base == nil {
+			if err := os.Remove(p); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := os.WriteFile(p, c.base.content, c.base.mode); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// runFormatter runs a formatter command and returns its changes in working
+// tree as diagnostics with suggestions. The working tree is restored after
+// running the command.
+func runFormatter(ctx context.Context, cmdBuilder *cmdBuilder, command string) (ds []*rdf.Diagnostic, cmdErr error, err error) {
+	// Cancel the command on interrupt to restore the working tree before exit.
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	s, err := takeSnapshot(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("fail to take snapshot of working tree: %w", err)
+	}
+	defer func() {
+		if rerr := s.restore(context.WithoutCancel(ctx)); rerr != nil {
+			err = errors.Join(err, fmt.Errorf("fail to restore working tree: %w", rerr))
+		}
+	}()
+	cmd, stdout, stderr, err := cmdBuilder.build(ctx, command)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, nil, fmt.Errorf("fail to start command: %w", err)
+	}
+	// Output of formatters is not used.
+	if _, err := io.Copy(io.Discard, io.MultiReader(stdout, stderr)); err != nil {
+		return nil, nil, err
+	}
+	cmdErr = cmd.Wait()
+	if ctx.Err() != nil {
+		return nil, nil, context.Cause(ctx)
+	}
+	d, err := s.diff(ctx)
+	if err != nil {
+		return nil, cmdErr, err
+	}
+	ds, err = parser.NewDiffParser(1).Parse(bytes.NewReader(d))
+	return ds, cmdErr, err
+}
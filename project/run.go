@@ -17,6 +17,7 @@ import (
 	"github.com/reviewdog/reviewdog/diff"
 	"github.com/reviewdog/reviewdog/filter"
 	"github.com/reviewdog/reviewdog/parser"
+	"github.com/reviewdog/reviewdog/proto/rdf"
 )
 
 // RunAndParse runs commands and parse results. Returns map of tool name to check results.
@@ -32,20 +33,53 @@ func RunAndParse(ctx context.Context, conf *Config, runners map[string]bool, def
 		semaphoreNum = 1
 	}
 	semaphore := make(chan int, semaphoreNum)
+	// Run formatters one by one before other runners as they modify working
+	// tree temporarily.
+	for key, runner := range conf.Runner {
+		runnerName := getRunnerName(key, runner)
+		if !runner.Formatter || (len(runners) != 0 && !runners[runnerName]) {
+			continue
+		}
+		usedRunners = append(usedRunners, runnerName)
+		if err := runFormatterRunner(ctx, cmdBuilder, &results, runnerName, runner, defaultLevel); err != nil {
+			return nil, err
+		}
+	}
 	for key, runner := range conf.Runner {
 		runner := runner
 		runnerName := getRunnerName(key, runner)
-		if len(runners) != 0 && !runners[runnerName] {
+		if runner.Formatter || (len(runners) != 0 && !runners[runnerName]) {
 			continue // Skip this runner.
 		}
 		usedRunners = append(usedRunners, runnerName)
 		semaphore <- 1
 		log.Printf("reviewdog: [start] runner=%s", runnerName)
 		fname := runner.Format
+		if len(runner.Formats) > 0 {
+			if fname != "" {
+				return nil, fmt.Errorf("runner %s: you cannot specify both format and formats at the same time", runnerName)
+			}
+			fname = strings.Join(runner.Formats, ",")
+		}
 		if fname == "" && len(runner.Errorformat) == 0 {
 			fname = runnerName
 		}
-		opt := &parser.Option{FormatName: fname, Errorformat: runner.Errorformat}
+		var lenient parser.LenientMode
+		if err := lenient.Set(runner.Lenient); err != nil {
+			return nil, fmt.Errorf("runner %s: %w", runnerName, err)
+		}
+		var columnUnit rdf.ColumnUnit
+		if err := columnUnit.Set(runner.ColumnUnit); err != nil {
+			return nil, fmt.Errorf("runner %s: %w", runnerName, err)
+		}
+		opt := &parser.Option{
+			FormatName:        fname,
+			Errorformat:       runner.Errorformat,
+			Lenient:           lenient,
+			Formats:           conf.Formats,
+			CheckStyleSources: conf.CheckStyleSources,
+			ColumnUnit:        columnUnit,
+		}
 		p, err := parser.New(opt)
 		if err != nil {
 			return nil, err
@@ -91,6 +125,33 @@ func RunAndParse(ctx context.Context, conf *Config, runners map[string]bool, def
 	return &results, nil
 }
 
+func runFormatterRunner(ctx context.Context, cmdBuilder *cmdBuilder, results *reviewdog.ResultMap, runnerName string, runner *Runner, defaultLevel string) error {
+	if runner.Format != "" || len(runner.Formats) > 0 || len(runner.Errorformat) > 0 {
+		return fmt.Errorf("runner %s: you cannot specify format or errorformat for formatter", runnerName)
+	}
+	log.Printf("reviewdog: [start] runner=%s (formatter)", runnerName)
+	diagnostics, cmdErr, err := runFormatter(ctx, cmdBuilder, runner.Cmd)
+	if err != nil {
+		return fmt.Errorf("runner %s: %w", runnerName, err)
+	}
+	level := runner.Level
+	if level == "" {
+		level = defaultLevel
+	}
+	results.Store(runnerName, &reviewdog.Result{
+		Name:        runnerName,
+		Level:       level,
+		Diagnostics: diagnostics,
+		CmdErr:      cmdErr,
+	})
+	msg := fmt.Sprintf("reviewdog: [finish] runner=%s", runnerName)
+	if cmdErr != nil {
+		msg += fmt.Sprintf("\terror=%v", cmdErr)
+	}
+	log.Println(msg)
+	return nil
+}
+
 // Run runs reviewdog tasks based on Config.
 func Run(ctx context.Context, conf *Config, runners map[string]bool, c reviewdog.CommentService, d reviewdog.DiffService,
 	teeMode bool, filterMode filter.Mode, failLevel reviewdog.FailLevel) error {
@@ -111,6 +172,11 @@ func Run(ctx context.Context, conf *Config, runners map[string]bool, c reviewdog
 		return err
 	}
 	var errs []error
+	cs := c
+	report, isReport := c.(reviewdog.ReportCommentService)
+	if isReport {
+		cs = &noFlushCommentService{CommentService: report}
+	}
 	results.Range(func(toolname string, result *reviewdog.Result) {
 		if err := result.CheckUnexpectedFailure(); err != nil {
 			errs = append(errs, err)
@@ -119,17 +185,45 @@ func Run(ctx context.Context, conf *Config, runners map[string]bool, c reviewdog
 			ncs.SetTool(toolname, result.Level)
 		}
 		// Note: CommentService shouldn't be run concurrently with different tool.
-		if err := reviewdog.RunFromResult(ctx, c, result.Diagnostics, filediffs, d.Strip(), toolname, filterMode, failLevel); err != nil {
+		if err := reviewdog.RunFromResult(ctx, cs, result.Diagnostics, filediffs, d.Strip(), toolname, filterMode, failLevel); err != nil {
 			errs = append(errs, err)
 		}
 	})
+	if isReport {
+		if err := report.Flush(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
 	return errors.Join(errs...)
 }
 
+// noFlushCommentService hides Flush() of ReportCommentService to write results
+// of all runners at once.
+type noFlushCommentService struct {
+	reviewdog.CommentService
+}
+
+// PostFiltered forwards filtered comments if the wrapped service supports
+// them. Otherwise, it drops them.
+func (s *noFlushCommentService) PostFiltered(ctx context.Context, c *reviewdog.Comment) error {
+	if fc, ok := s.CommentService.(reviewdog.FilteredCommentService); ok {
+		return fc.PostFiltered(ctx, c)
+	}
+	return nil
+}
+
+// SupportsOldLines returns true if the wrapped service supports results in
+// the old file.
+func (s *noFlushCommentService) SupportsOldLines() bool {
+	oc, ok := s.CommentService.(reviewdog.OldLineCommentService)
+	return ok && oc.SupportsOldLines()
+}
+
 var secretEnvs = [...]string{
 	"REVIEWDOG_GITHUB_API_TOKEN",
 	"REVIEWDOG_GITLAB_API_TOKEN",
 	"REVIEWDOG_TOKEN",
+	"REVIEWDOG_WEBHOOK_SECRET",
 }
 
 func filteredEnviron() []string {
@@ -3,12 +3,16 @@ package project
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"os"
+	"os/exec"
 	"runtime"
 	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+
 	"github.com/reviewdog/reviewdog"
 	"github.com/reviewdog/reviewdog/filter"
 )
@@ -296,6 +300,215 @@ func TestRun(t *testing.T) {
 	})
 }
 
+func TestRun_report(t *testing.T) {
+	ds := &fakeDiffService{
+		FakeDiff: func() ([]byte, error) {
+			return []byte(""), nil
+		},
+	}
+	conf := &Config{
+		Runner: map[string]*Runner{
+			"tool1": {
+				Cmd:         "echo 'a.go:1:1: msg1'",
+				Errorformat: []string{`%f:%l:%c: %m`},
+			},
+			"tool2": {
+				Cmd:         "echo 'b.go:2:1: msg2'",
+				Errorformat: []string{`%f:%l:%c: %m`},
+			},
+		},
+	}
+	buf := new(bytes.Buffer)
+	cs := reviewdog.NewCodeQualityCommentWriter(buf)
+	if err := Run(context.Background(), conf, nil, cs, ds, false, filter.ModeNoFilter, reviewdog.FailLevelNone); err != nil {
+		t.Fatal(err)
+	}
+	// Results of all runners should be written as one report.
+	var issues []*reviewdog.CodeQualityIssue
+	if err := json.Unmarshal(buf.Bytes(), &issues); err != nil {
+		t.Fatalf("invalid report: %v\n%s", err, buf.String())
+	}
+	if len(issues) != 2 {
+		t.Errorf("got %d issues, want 2", len(issues))
+	}
+}
+
+type fakeReportCommentService struct {
+	fakeCommentService
+	filtered []string
+}
+
+func (*fakeReportCommentService) Flush(_ context.Context) error { return nil }
+
+func (f *fakeReportCommentService) PostFiltered(_ context.Context, c *reviewdog.Comment) error {
+	f.filtered = append(f.filtered, c.Result.Diagnostic.GetMessage())
+	return nil
+}
+
+func (*fakeReportCommentService) SupportsOldLines() bool { return true }
+
+func TestRun_reportFilteredAndOldLines(t *testing.T) {
+	ds := &fakeDiffService{
+		FakeDiff: func() ([]byte, error) {
+			return []byte(`--- a.go
++++ a.go
+@@ -1,2 +1,1 @@
+-deleted
+ kept
+`), nil
+		},
+	}
+	conf := &Config{
+		Runner: map[string]*Runner{
+			"tool": {
+				Cmd: `printf '%s\n' '{"message":"old","location":{"path":"a.go","range":{"start":{"line":1}},"old":true}}' ` +
+					`'{"message":"filtered","location":{"path":"b.go","range":{"start":{"line":1}}}}'`,
+				Format: "rdjsonl",
+			},
+		},
+	}
+	var posted []string
+	f := &fakeReportCommentService{fakeCommentService: fakeCommentService{
+		FakePost: func(c *reviewdog.Comment) error {
+			posted = append(posted, c.Result.Diagnostic.GetMessage())
+			return nil
+		},
+	}}
+	cs := reviewdog.NewReportCommentService(f)
+	if err := Run(context.Background(), conf, nil, cs, ds, false, filter.ModeAdded, reviewdog.FailLevelNone); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"old"}, posted); diff != "" {
+		t.Errorf("posted results diff (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"filtered"}, f.filtered); diff != "" {
+		t.Errorf("filtered results diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestRunAndParse_formatter(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("formatter command uses sh")
+	}
+	t.Chdir(t.TempDir())
+	const original = "package main\n\nfunc  main() {\n}\n"
+	if err := os.WriteFile("main.go", []byte(original), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	conf := &Config{
+		Runner: map[string]*Runner{
+			"fmt": {
+				Cmd:       `printf 'package main\n\nfunc main() {\n}\n' > main.go && touch created.go`,
+				Formatter: true,
+			},
+		},
+	}
+	results, err := RunAndParse(context.Background(), conf, nil, "warning", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := results.Load("fmt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Diagnostics) != 1 {
+		t.Fatalf("got %d diagnostics, want 1", len(result.Diagnostics))
+	}
+	d := result.Diagnostics[0]
+	if got, want := d.GetLocation().GetPath(), "main.go"; got != want {
+		t.Errorf("path = %q, want %q", got, want)
+	}
+	if got, want := d.GetSuggestions()[0].GetText(), "func main() {"; got != want {
+		t.Errorf("suggestion = %q, want %q", got, want)
+	}
+	if got, want := d.GetLocation().GetRange().GetStart().GetLine(), int32(3); got != want {
+		t.Errorf("line = %d, want %d", got, want)
+	}
+	b, err := os.ReadFile("main.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != original {
+		t.Errorf("working tree is not restored: %q", b)
+	}
+	if _, err := os.Stat("created.go"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("created file is not removed: %v", err)
+	}
+}
+
+func TestRunAndParse_formatter_git(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("formatter command uses sh")
+	}
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	t.Chdir(t.TempDir())
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	files := map[string]string{
+		"clean.go":    "package main\n\nfunc  a() {\n}\n",
+		"modified.go": "package main\n",
+		".gitignore":  "ignored.go\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(name, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	git("init", "-q")
+	git("add", ".")
+	git("commit", "-q", "-m", "init")
+	// Modified in working tree before running the formatter.
+	const modified = "package main\n\nfunc  b() {\n}\n"
+	if err := os.WriteFile("modified.go", []byte(modified), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	files["modified.go"] = modified
+
+	conf := &Config{
+		Runner: map[string]*Runner{
+			"fmt": {
+				Cmd: `printf 'package main\n\nfunc a() {\n}\n' > clean.go && ` +
+					`printf 'package main\n\nfunc b() {\n}\n' > modified.go && touch created.go ignored.go`,
+				Formatter: true,
+			},
+		},
+	}
+	results, err := RunAndParse(context.Background(), conf, nil, "warning", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := results.Load("fmt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, d := range result.Diagnostics {
+		got = append(got, d.GetLocation().GetPath()+": "+d.GetSuggestions()[0].GetText())
+	}
+	want := []string{"clean.go: func a() {", "modified.go: func b() {"}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	for name, content := range files {
+		if b, err := os.ReadFile(name); err != nil || string(b) != content {
+			t.Errorf("%s is not restored: %q, %v", name, b, err)
+		}
+	}
+	if _, err := os.Stat("created.go"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("created file is not removed: %v", err)
+	}
+	if _, err := os.Stat("ignored.go"); err != nil {
+		t.Errorf("ignored file should be kept: %v", err)
+	}
+}
+
 func TestFilteredEnviron(t *testing.T) {
 	names := [...]string{
 		"REVIEWDOG_GITHUB_API_TOKEN",
@@ -0,0 +1,148 @@
+package rdf
+
+import (
+	"bytes"
+	"fmt"
+	"unicode/utf8"
+)
+
+// ColumnUnit represents enumeration of units of columns which tools report.
+// Column of Position is counted in UTF-8 bytes.
+type ColumnUnit int
+
+const (
+	// ColumnUnitByte represents columns counted in UTF-8 bytes.
+	ColumnUnitByte ColumnUnit = iota
+	// ColumnUnitUTF16 represents columns counted in UTF-16 code units (e.g.
+	// index of JavaScript string).
+	ColumnUnitUTF16
+	// ColumnUnitCodePoint represents columns counted in Unicode code points.
+	ColumnUnitCodePoint
+)
+
+// String implements the flag.Value interface
+func (unit *ColumnUnit) String() string {
+	names := [...]string{
+		"byte",
+		"utf16",
+		"codepoint",
+	}
+	if *unit < ColumnUnitByte || *unit > ColumnUnitCodePoint {
+		return "Unknown column unit"
+	}
+	return names[*unit]
+}
+
+// Set implements the flag.Value interface
+func (unit *ColumnUnit) Set(value string) error {
+	switch value {
+	case "byte", "":
+		*unit = ColumnUnitByte
+	case "utf16":
+		*unit = ColumnUnitUTF16
+	case "codepoint":
+		*unit = ColumnUnitCodePoint
+	default:
+		return fmt.Errorf("invalid column unit name: %s", value)
+	}
+	return nil
+}
+
+// ByteColumn converts 1-based column in line counted in unit to 1-based
+// column in UTF-8 bytes. column == (length of line) + 1 is valid and points to
+// the end of the line.
+func ByteColumn(line []byte, column int, unit ColumnUnit) (int, error) {
+	if column < 1 {
+		return 0, fmt.Errorf("invalid column %d", column)
+	}
+	offset := 0
+	for c := 1; c < column; {
+		if offset >= len(line) {
+			return 0, fmt.Errorf("column %d is out of range", column)
+		}
+		r, size := utf8.DecodeRune(line[offset:])
+		offset += size
+		switch {
+		case unit == ColumnUnitByte:
+			c += size
+		case unit == ColumnUnitUTF16 && r >= 0x10000:
+			c += 2 // surrogate pair
+		default:
+			c++
+		}
+		if c > column {
+			return 0, fmt.Errorf("column %d points to the middle of a character", column)
+		}
+	}
+	return offset + 1, nil
+}
+
+// NormalizeColumns converts columns of positions in diagnostics counted in
+// unit to UTF-8 bytes in place. It reads file contents with readFile. Positions
+// in unreadable files or out of range are left as is.
+func NormalizeColumns(ds []*Diagnostic, unit ColumnUnit, readFile func(path string) ([]byte, error)) {
+	if unit == ColumnUnitByte {
+		return
+	}
+	srcs := make(map[string][]byte)
+	src := func(path string) []byte {
+		b, ok := srcs[path]
+		if !ok {
+			b, _ = readFile(path)
+			srcs[path] = b
+		}
+		return b
+	}
+	// Positions may be shared by ranges (e.g. location and suggestion).
+	converted := make(map[*Position]bool)
+	convert := func(path string, rng *Range) {
+		if rng == nil || path == "" {
+			return
+		}
+		s := src(path)
+		if s == nil {
+			return
+		}
+		for _, pos := range []*Position{rng.GetStart(), rng.GetEnd()} {
+			if pos.GetColumn() <= 0 || converted[pos] {
+				continue
+			}
+			line, ok := SourceLine(s, int(pos.GetLine()))
+			if !ok {
+				continue
+			}
+			if col, err := ByteColumn(line, int(pos.GetColumn()), unit); err == nil {
+				pos.Column = int32(col)
+				converted[pos] = true
+			}
+		}
+	}
+	for _, d := range ds {
+		path := d.GetLocation().GetPath()
+		convert(path, d.GetLocation().GetRange())
+		for _, s := range d.GetSuggestions() {
+			convert(path, s.GetRange())
+		}
+		for _, rl := range d.GetRelatedLocations() {
+			convert(rl.GetLocation().GetPath(), rl.GetLocation().GetRange())
+		}
+	}
+}
+
+// SourceLine returns the content of 1-based line in src without the newline.
+func SourceLine(src []byte, line int) ([]byte, bool) {
+	if line < 1 {
+		return nil, false
+	}
+	for l := 1; l < line; l++ {
+		i := bytes.IndexByte(src, '\n')
+		if i < 0 {
+			return nil, false
+		}
+		src = src[i+1:]
+	}
+	if i := bytes.IndexByte(src, '\n'); i >= 0 {
+		src = src[:i]
+	}
+	return src, true
+}
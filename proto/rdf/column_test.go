@@ -0,0 +1,103 @@
+package rdf
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func TestByteColumn(t *testing.T) {
+	line := []byte("aé😀b") // 1 + 2 + 4 + 1 bytes, 1 + 1 + 2 + 1 UTF-16 units.
+	tests := []struct {
+		unit    ColumnUnit
+		column  int
+		want    int
+		wantErr bool
+	}{
+		{unit: ColumnUnitByte, column: 4, want: 4},
+		{unit: ColumnUnitByte, column: 3, wantErr: true},
+		{unit: ColumnUnitCodePoint, column: 3, want: 4},
+		{unit: ColumnUnitCodePoint, column: 4, want: 8},
+		{unit: ColumnUnitCodePoint, column: 5, want: 9},
+		{unit: ColumnUnitCodePoint, column: 6, wantErr: true},
+		{unit: ColumnUnitUTF16, column: 3, want: 4},
+		{unit: ColumnUnitUTF16, column: 4, wantErr: true},
+		{unit: ColumnUnitUTF16, column: 5, want: 8},
+		{unit: ColumnUnitUTF16, column: 0, wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ByteColumn(line, tt.column, tt.unit)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ByteColumn(%d, %s) = %d, want error", tt.column, tt.unit.String(), got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ByteColumn(%d, %s) got error: %v", tt.column, tt.unit.String(), err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ByteColumn(%d, %s) = %d, want %d", tt.column, tt.unit.String(), got, tt.want)
+		}
+	}
+}
+
+func TestNormalizeColumns(t *testing.T) {
+	files := map[string]string{
+		"a.js": "const s = '😀'; x\nnext\n",
+	}
+	readFile := func(path string) ([]byte, error) {
+		if s, ok := files[path]; ok {
+			return []byte(s), nil
+		}
+		return nil, errors.New("not found")
+	}
+	rng := &Range{
+		Start: &Position{Line: 1, Column: 16},
+		End:   &Position{Line: 1, Column: 17},
+	}
+	ds := []*Diagnostic{
+		{
+			Location: &Location{Path: "a.js", Range: rng},
+			// Shares the range with the location.
+			Suggestions: []*Suggestion{{Range: rng, Text: "y"}},
+		},
+		{
+			Location: &Location{
+				Path:  "unknown.js",
+				Range: &Range{Start: &Position{Line: 1, Column: 16}},
+			},
+		},
+	}
+	NormalizeColumns(ds, ColumnUnitUTF16, readFile)
+	want := []*Diagnostic{
+		{
+			Location: &Location{
+				Path: "a.js",
+				Range: &Range{
+					Start: &Position{Line: 1, Column: 18},
+					End:   &Position{Line: 1, Column: 19},
+				},
+			},
+			Suggestions: []*Suggestion{{
+				Range: &Range{
+					Start: &Position{Line: 1, Column: 18},
+					End:   &Position{Line: 1, Column: 19},
+				},
+				Text: "y",
+			}},
+		},
+		{
+			Location: &Location{
+				Path:  "unknown.js",
+				Range: &Range{Start: &Position{Line: 1, Column: 16}},
+			},
+		},
+	}
+	if diff := cmp.Diff(ds, want, protocmp.Transform()); diff != "" {
+		t.Errorf("diff (-got +want):\n%s", diff)
+	}
+}
@@ -0,0 +1,10 @@
+// Package jsonschema provides JSON Schema files of Reviewdog Diagnostic Format
+// generated from reviewdog.proto.
+package jsonschema
+
+import "embed"
+
+// FS contains JSON Schema files (e.g. Diagnostic.json, DiagnosticResult.json).
+//
+//go:embed *.json
+var FS embed.FS
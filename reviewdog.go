@@ -65,6 +65,24 @@ type BulkCommentService interface {
 	Flush(context.Context) error
 }
 
+// ReportCommentService is a BulkCommentService which writes comments as a
+// single report (e.g. an artifact of CI). Flush() is called once after all
+// runners in project config based run, so that the report aggregates results
+// of all runners.
+type ReportCommentService interface {
+	BulkCommentService
+	report()
+}
+
+// OldLineCommentService is a CommentService which supports results in the old
+// file (Location.old), e.g. comments on deleted lines. Results in the old file
+// are not posted to other CommentServices as they would be reported against
+// the new file.
+type OldLineCommentService interface {
+	CommentService
+	SupportsOldLines() bool
+}
+
 // NamedCommentService can set tool name and level. Useful for update tool name
 // for each reviewdog run with reviewdog project config.
 type NamedCommentService interface {
@@ -98,8 +116,13 @@ func (w *Reviewdog) runFromResult(ctx context.Context, results []*rdf.Diagnostic
 
 	checks := filter.FilterCheck(results, filediffs, strip, wd, w.filterMode)
 	shouldFail := false
+	oc, ok := w.c.(OldLineCommentService)
+	supportsOldLines := ok && oc.SupportsOldLines()
 
 	for _, check := range checks {
+		if check.Diagnostic.GetLocation().GetOld() && !supportsOldLines {
+			continue
+		}
 		comment := &Comment{
 			Result:   check,
 			ToolName: w.toolname,
@@ -223,3 +223,61 @@ golint.new.go:11:1: comment on exported function F2 should be of the form "F2 ..
 		t.Errorf("'input data has violations' expected, but got %v", err)
 	}
 }
+
+type oldLineTestWriter struct {
+	testWriter
+}
+
+func (*oldLineTestWriter) SupportsOldLines() bool { return true }
+
+func TestReviewdog_Run_old_lines(t *testing.T) {
+	difftext := `diff --git a/a.go b/a.go
+--- a/a.go
++++ b/a.go
+@@ -1,3 +1,3 @@
+ package a
+-var deleted int
++var added int
+ var unchanged int
+`
+	lintresult := `{"message":"deleted","location":{"path":"a.go","range":{"start":{"line":2}},"old":true}}
+{"message":"added","location":{"path":"a.go","range":{"start":{"line":2}}}}
+`
+	tests := []struct {
+		name string
+		c    func(post func(c *Comment) error) CommentService
+		want []string
+	}{
+		{
+			name: "not supported",
+			c: func(post func(c *Comment) error) CommentService {
+				return &testWriter{FakePost: post}
+			},
+			want: []string{"added"},
+		},
+		{
+			name: "supported",
+			c: func(post func(c *Comment) error) CommentService {
+				return &oldLineTestWriter{testWriter{FakePost: post}}
+			},
+			want: []string{"deleted", "added"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			c := tt.c(func(c *Comment) error {
+				got = append(got, c.Result.Diagnostic.GetMessage())
+				return nil
+			})
+			d := NewDiffString(difftext, 1)
+			app := NewReviewdog("tool name", &parser.RDJSONLParser{}, c, d, filter.ModeAdded, FailLevelDefault)
+			if err := app.Run(context.Background(), strings.NewReader(lintresult)); err != nil {
+				t.Fatal(err)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
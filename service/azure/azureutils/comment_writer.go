@@ -0,0 +1,119 @@
+package azureutils
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/reviewdog/reviewdog"
+	"github.com/reviewdog/reviewdog/proto/rdf"
+)
+
+// MaxLogIssues is the max number of issues which AzurePipelinesLogWriter
+// reports as logging commands per tool. Too many issues make the build summary
+// unreadable and the rest of results are written as plain log lines.
+const MaxLogIssues = 100
+
+var _ reviewdog.BulkCommentService = &AzurePipelinesLogWriter{}
+
+// AzurePipelinesLogWriter reports results via task.logissue logging command
+// to create issues (annotations) of Azure Pipelines.
+// https://learn.microsoft.com/en-us/azure/devops/pipelines/scripts/logging-commands#logissue-log-an-error-or-warning
+type AzurePipelinesLogWriter struct {
+	w         io.Writer
+	level     string
+	maxIssues int
+	reportNum int
+}
+
+// NewAzurePipelinesLogWriter returns new AzurePipelinesLogWriter.
+func NewAzurePipelinesLogWriter(w io.Writer, level string) *AzurePipelinesLogWriter {
+	return &AzurePipelinesLogWriter{w: w, level: level, maxIssues: MaxLogIssues}
+}
+
+func (lw *AzurePipelinesLogWriter) Post(_ context.Context, c *reviewdog.Comment) error {
+	lw.reportNum++
+	d := c.Result.Diagnostic
+	mes := fmt.Sprintf("[%s] %s", c.ToolName, d.GetMessage())
+	if lw.reportNum > lw.maxIssues {
+		_, err := fmt.Fprintf(lw.w, "%s: %s\n", location(d), mes)
+		return err
+	}
+	_, err := fmt.Fprintln(lw.w, LogIssueCommand(lw.level, mes, d))
+	return err
+}
+
+func (*AzurePipelinesLogWriter) ShouldPrependGitRelDir() bool { return true }
+
+// Flush reports the number of results which are not reported as issues and
+// resets the count for the next tool.
+func (lw *AzurePipelinesLogWriter) Flush(_ context.Context) error {
+	reportNum := lw.reportNum
+	lw.reportNum = 0
+	if reportNum <= lw.maxIssues {
+		return nil
+	}
+	mes := fmt.Sprintf("reviewdog: Too many results. %d of %d results are not reported as issues. Please check the log to see all results.",
+		reportNum-lw.maxIssues, reportNum)
+	_, err := fmt.Fprintf(lw.w, "##vso[task.logissue type=warning]%s\n", escapeData(mes))
+	return err
+}
+
+// LogIssueCommand returns task.logissue logging command of the diagnostic with
+// the message. defaultLevel is used if the diagnostic doesn't have severity.
+func LogIssueCommand(defaultLevel, message string, d *rdf.Diagnostic) string {
+	typ := "error"
+	switch d.GetSeverity() {
+	case rdf.Severity_ERROR:
+	case rdf.Severity_WARNING, rdf.Severity_INFO:
+		typ = "warning"
+	default:
+		// No info type in Azure Pipelines.
+		if defaultLevel == "warning" || defaultLevel == "info" {
+			typ = "warning"
+		}
+	}
+	props := []string{"type=" + typ}
+	loc := d.GetLocation()
+	if path := loc.GetPath(); path != "" {
+		props = append(props, "sourcepath="+escapeProperty(path))
+		start := loc.GetRange().GetStart()
+		if start.GetLine() > 0 {
+			props = append(props, fmt.Sprintf("linenumber=%d", start.GetLine()))
+			if start.GetColumn() > 0 {
+				props = append(props, fmt.Sprintf("columnnumber=%d", start.GetColumn()))
+			}
+		}
+	}
+	if code := d.GetCode().GetValue(); code != "" {
+		props = append(props, "code="+escapeProperty(code))
+	}
+	return fmt.Sprintf("##vso[task.logissue %s]%s", strings.Join(props, ";"), escapeData(message))
+}
+
+var (
+	dataEscaper     = strings.NewReplacer("%", "%AZP25", "\r", "%0D", "\n", "%0A")
+	propertyEscaper = strings.NewReplacer("%", "%AZP25", "\r", "%0D", "\n", "%0A", ";", "%3B", "]", "%5D")
+)
+
+func escapeData(s string) string {
+	return dataEscaper.Replace(s)
+}
+
+func escapeProperty(s string) string {
+	return propertyEscaper.Replace(s)
+}
+
+// location returns <file>[:<lnum>[:<col>]] of the diagnostic.
+func location(d *rdf.Diagnostic) string {
+	s := d.GetLocation().GetPath()
+	start := d.GetLocation().GetRange().GetStart()
+	if start.GetLine() > 0 {
+		s += fmt.Sprintf(":%d", start.GetLine())
+		if start.GetColumn() > 0 {
+			s += fmt.Sprintf(":%d", start.GetColumn())
+		}
+	}
+	return s
+}
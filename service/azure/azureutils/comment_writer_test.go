@@ -0,0 +1,129 @@
+package azureutils
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/reviewdog/reviewdog"
+	"github.com/reviewdog/reviewdog/filter"
+	"github.com/reviewdog/reviewdog/parser"
+	"github.com/reviewdog/reviewdog/proto/rdf"
+)
+
+func TestAzurePipelinesLogWriter(t *testing.T) {
+	comments := []*reviewdog.Comment{
+		{
+			Result: &filter.FilteredDiagnostic{Diagnostic: &rdf.Diagnostic{
+				Location: &rdf.Location{
+					Path:  "dir/a;b].go",
+					Range: &rdf.Range{Start: &rdf.Position{Line: 14, Column: 3}},
+				},
+				Message:  "100% wrong\nsecond line",
+				Severity: rdf.Severity_ERROR,
+				Code:     &rdf.Code{Value: "rule;1"},
+			}},
+			ToolName: "golint",
+		},
+		{
+			Result: &filter.FilteredDiagnostic{Diagnostic: &rdf.Diagnostic{
+				Location: &rdf.Location{Path: "b.go", Range: &rdf.Range{Start: &rdf.Position{Line: 1}}},
+				Message:  "info",
+				Severity: rdf.Severity_INFO,
+			}},
+			ToolName: "golint",
+		},
+		{
+			Result: &filter.FilteredDiagnostic{Diagnostic: &rdf.Diagnostic{
+				Location: &rdf.Location{Path: "c.go"},
+				Message:  "default level",
+			}},
+			ToolName: "golint",
+		},
+		{
+			Result: &filter.FilteredDiagnostic{Diagnostic: &rdf.Diagnostic{
+				Location: &rdf.Location{Path: "d.go", Range: &rdf.Range{Start: &rdf.Position{Line: 2, Column: 1}}},
+				Message:  "overflow",
+			}},
+			ToolName: "golint",
+		},
+	}
+	buf := new(bytes.Buffer)
+	lw := NewAzurePipelinesLogWriter(buf, "warning")
+	lw.maxIssues = 3
+	for _, c := range comments {
+		if err := lw.Post(context.Background(), c); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := lw.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	want := `##vso[task.logissue type=error;sourcepath=dir/a%3Bb%5D.go;linenumber=14;columnnumber=3;code=rule%3B1][golint] 100%AZP25 wrong%0Asecond line
+##vso[task.logissue type=warning;sourcepath=b.go;linenumber=1][golint] info
+##vso[task.logissue type=warning;sourcepath=c.go][golint] default level
+d.go:2:1: [golint] overflow
+##vso[task.logissue type=warning]reviewdog: Too many results. 1 of 4 results are not reported as issues. Please check the log to see all results.
+`
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("result has diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestAzurePipelinesLogWriter_flushPerTool(t *testing.T) {
+	buf := new(bytes.Buffer)
+	lw := NewAzurePipelinesLogWriter(buf, "warning")
+	lw.maxIssues = 1
+	// Each runner in project mode flushes its results.
+	for _, tool := range []string{"golint", "govet"} {
+		c := &reviewdog.Comment{
+			Result: &filter.FilteredDiagnostic{Diagnostic: &rdf.Diagnostic{
+				Location: &rdf.Location{Path: "a.go"},
+				Message:  "msg",
+			}},
+			ToolName: tool,
+		}
+		if err := lw.Post(context.Background(), c); err != nil {
+			t.Fatal(err)
+		}
+		if err := lw.Flush(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := `##vso[task.logissue type=warning;sourcepath=a.go][golint] msg
+##vso[task.logissue type=warning;sourcepath=a.go][govet] msg
+`
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("result has diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestLogIssueCommand_roundTrip(t *testing.T) {
+	d := &rdf.Diagnostic{
+		Location: &rdf.Location{
+			Path:  "a;b].go",
+			Range: &rdf.Range{Start: &rdf.Position{Line: 3, Column: 5}},
+		},
+		Message:  "50% done\r\nnext]",
+		Severity: rdf.Severity_WARNING,
+		Code:     &rdf.Code{Value: "c;1"},
+	}
+	cmd := LogIssueCommand("", d.GetMessage(), d)
+	got, err := parser.NewAzureLogIssueParser().Parse(bytes.NewBufferString(cmd))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*rdf.Diagnostic{{
+		Location:       d.Location,
+		Message:        d.Message,
+		Severity:       d.Severity,
+		Code:           d.Code,
+		OriginalOutput: cmd,
+	}}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("round trip has diff (-want +got):\n%s", diff)
+	}
+}
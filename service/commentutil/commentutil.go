@@ -16,7 +16,7 @@ type PostedComments map[string]map[int][]string
 // otherwise returns false. It sees comments with same path, same position,
 // and same body as same comments.
 func (p PostedComments) IsPosted(c *reviewdog.Comment, lineNum int, bodyOrFingerprint string) bool {
-	path := c.Result.Diagnostic.GetLocation().GetPath()
+	path := CommentPath(c)
 	if _, ok := p[path]; !ok {
 		return false
 	}
@@ -32,6 +32,17 @@ func (p PostedComments) IsPosted(c *reviewdog.Comment, lineNum int, bodyOrFinger
 	return false
 }
 
+// CommentPath returns the path of the file which the comment is posted to. It's
+// the path of the new file for results in the old file (Location.old), e.g. the
+// new name of a renamed file, as code review services identify files of
+// comments on deleted lines by the new path as well.
+func CommentPath(c *reviewdog.Comment) string {
+	if c.Result.Diagnostic.GetLocation().GetOld() && c.Result.NewPath != "" {
+		return c.Result.NewPath
+	}
+	return c.Result.Diagnostic.GetLocation().GetPath()
+}
+
 // AddPostedComment adds a posted comment.
 func (p PostedComments) AddPostedComment(path string, lineNum int, bodyOrFingerprint string) {
 	if _, ok := p[path]; !ok {
@@ -23,6 +23,7 @@ import (
 
 var _ reviewdog.CommentService = (*PullRequest)(nil)
 var _ reviewdog.DiffService = (*PullRequest)(nil)
+var _ reviewdog.OldLineCommentService = (*PullRequest)(nil)
 
 const maxCommentsPerRequest = 30
 
@@ -42,6 +43,11 @@ func isPermissionError(err error) bool {
 	return status == http.StatusForbidden || status == http.StatusNotFound
 }
 
+func isUnprocessableError(err error) bool {
+	var githubErr *github.ErrorResponse
+	return errors.As(err, &githubErr) && githubErr.Response.StatusCode == http.StatusUnprocessableEntity
+}
+
 // PullRequest is a comment and diff service for GitHub PullRequest.
 //
 // API:
@@ -64,6 +70,11 @@ type PullRequest struct {
 	postedcs           commentutil.PostedComments
 	outdatedComments   map[string]*github.PullRequestComment // fingerprint -> comment
 	prCommentWithReply map[int64]bool                        // review id -> bool
+
+	resolveThreads bool
+
+	reviewEvent ReviewEvent
+	failLevel   reviewdog.FailLevel
 }
 
 // NewGitHubPullRequest returns a new PullRequest service.
@@ -98,6 +109,11 @@ func (g *PullRequest) Post(_ context.Context, c *reviewdog.Comment) error {
 
 func (*PullRequest) ShouldPrependGitRelDir() bool { return true }
 
+// SupportsOldLines returns true as review comments can be posted on the LEFT
+// side of the diff. Results in the old file are not reported to the GitHub
+// Actions log on fallback.
+func (*PullRequest) SupportsOldLines() bool { return true }
+
 // Flush posts comments which has not been posted yet.
 func (g *PullRequest) Flush(ctx context.Context) error {
 	g.muComments.Lock()
@@ -115,12 +131,19 @@ func (g *PullRequest) SetTool(toolName string, level string) {
 	g.logWriter = githubutils.NewGitHubActionLogWriter(level)
 }
 
+// SetResolveOutdatedThreads sets whether to resolve review threads of outdated
+// comments instead of deleting the comments. The resolved threads are
+// unresolved when the same results are reported again.
+func (g *PullRequest) SetResolveOutdatedThreads(resolve bool) {
+	g.resolveThreads = resolve
+}
+
 func (g *PullRequest) postAsReviewComment(ctx context.Context) error {
 	if g.fallbackToLog {
 		// we don't have permission to post a review comment.
 		// Fallback to GitHub Actions log as report.
 		for _, c := range g.postComments {
-			if err := g.logWriter.Post(ctx, c); err != nil {
+			if err := g.postToLog(ctx, c); err != nil {
 				return err
 			}
 		}
@@ -133,6 +156,7 @@ func (g *PullRequest) postAsReviewComment(ctx context.Context) error {
 	reviewComments := make([]*github.DraftReviewComment, 0, len(postComments))
 	fileComments := make([]*github.PullRequestComment, 0)
 	remaining := make([]*reviewdog.Comment, 0)
+	reportedComments := make([]*github.PullRequestComment, 0) // posted comments which are reported again
 	rootPath, err := serviceutil.GetGitRoot()
 	if err != nil {
 		return err
@@ -146,7 +170,7 @@ func (g *PullRequest) postAsReviewComment(ctx context.Context) error {
 			// GitHub Review API cannot report results outside diff file. If it's running
 			// in GitHub Actions, fallback to GitHub Actions log as report.
 			if cienv.IsInGitHubAction() {
-				if err := g.logWriter.Post(ctx, c); err != nil {
+				if err := g.postToLog(ctx, c); err != nil {
 					return err
 				}
 			}
@@ -158,6 +182,9 @@ func (g *PullRequest) postAsReviewComment(ctx context.Context) error {
 		}
 		if g.postedcs.IsPosted(c, githubCommentLine(c), fprint) {
 			// it's already posted. Mark the comment as non-outdated and skip it.
+			if pc, ok := g.outdatedComments[fprint]; ok {
+				reportedComments = append(reportedComments, pc)
+			}
 			delete(g.outdatedComments, fprint)
 			continue
 		}
@@ -190,15 +217,40 @@ func (g *PullRequest) postAsReviewComment(ctx context.Context) error {
 		return err
 	}
 
-	if len(reviewComments) > 0 || len(remaining) > 0 {
+	event, eventBody, needsReview := "COMMENT", "", false
+	if g.reviewEvent != ReviewEventComment {
+		event, eventBody, needsReview, err = g.decideReviewEvent(ctx, postComments)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(reviewComments) > 0 || len(remaining) > 0 || needsReview {
+		summary := g.remainingCommentsSummary(remaining, repoBaseHTMLURL, rootPath)
+		body := summary
+		if eventBody != "" {
+			body = strings.TrimSpace(body + "\n\n" + eventBody)
+		}
 		// send review comments to GitHub.
 		review := &github.PullRequestReviewRequest{
 			CommitID: &g.sha,
-			Event:    github.Ptr("COMMENT"),
+			Event:    github.Ptr(event),
 			Comments: reviewComments,
-			Body:     github.Ptr(g.remainingCommentsSummary(remaining, repoBaseHTMLURL, rootPath)),
+			Body:     github.Ptr(body),
 		}
 		_, _, err := g.cli.PullRequests.CreateReview(ctx, g.owner, g.repo, g.pr, review)
+		if err != nil && event != "COMMENT" && isUnprocessableError(err) {
+			// GitHub returns 422 if the token cannot submit the event (e.g.
+			// approving own pull requests). Retry as COMMENT so that review
+			// comments are not lost.
+			log.Printf("reviewdog: failed to submit %s review, retrying as COMMENT: %v", event, err)
+			err = nil
+			if len(reviewComments) > 0 || len(remaining) > 0 {
+				review.Event = github.Ptr("COMMENT")
+				review.Body = github.Ptr(summary)
+				_, _, err = g.cli.PullRequests.CreateReview(ctx, g.owner, g.repo, g.pr, review)
+			}
+		}
 		if err != nil {
 			log.Printf("reviewdog: failed to post a review comment: %v", err)
 			// GitHub returns 403 or 404 if we don't have permission to post a review comment.
@@ -221,6 +273,9 @@ func (g *PullRequest) postAsReviewComment(ctx context.Context) error {
 		}
 	}
 
+	if g.resolveThreads {
+		return g.updateReviewThreads(ctx, reportedComments)
+	}
 	for _, c := range g.outdatedComments {
 		if ok := g.prCommentWithReply[c.GetID()]; ok {
 			// Do not remove comment with replies.
@@ -243,35 +298,81 @@ github-pr-check reporter as a fallback.
 	g.fallbackToLog = true
 
 	for _, c := range rawComments {
-		if err := g.logWriter.Post(ctx, c); err != nil {
+		if err := g.postToLog(ctx, c); err != nil {
 			return err
 		}
 	}
 	return g.logWriter.Flush(ctx)
 }
 
+// postToLog posts the comment to GitHub Actions log. Results in the old file
+// are skipped as annotations are always on the new file.
+func (g *PullRequest) postToLog(ctx context.Context, c *reviewdog.Comment) error {
+	if c.Result.Diagnostic.GetLocation().GetOld() {
+		return nil
+	}
+	return g.logWriter.Post(ctx, c)
+}
+
+// updateReviewThreads resolves review threads of outdated comments and
+// unresolves resolved threads of the reported comments.
+func (g *PullRequest) updateReviewThreads(ctx context.Context, reported []*github.PullRequestComment) error {
+	if len(g.outdatedComments) == 0 && len(reported) == 0 {
+		return nil
+	}
+	threads, err := listReviewThreads(ctx, g.cli, g.owner, g.repo, g.pr)
+	if err != nil {
+		return err
+	}
+	for _, c := range g.outdatedComments {
+		if t, ok := threads[c.GetID()]; ok && !t.IsResolved {
+			if err := resolveReviewThread(ctx, g.cli, t.ID, true); err != nil {
+				return fmt.Errorf("failed to resolve review thread (comment id=%d): %w", c.GetID(), err)
+			}
+		}
+	}
+	for _, c := range reported {
+		if t, ok := threads[c.GetID()]; ok && t.IsResolved {
+			if err := resolveReviewThread(ctx, g.cli, t.ID, false); err != nil {
+				return fmt.Errorf("failed to unresolve review thread (comment id=%d): %w", c.GetID(), err)
+			}
+		}
+	}
+	return nil
+}
+
 // Document: https://docs.github.com/en/rest/reference/pulls#create-a-review-comment-for-a-pull-request
 func buildDraftReviewComment(c *reviewdog.Comment, body string) *github.DraftReviewComment {
 	loc := c.Result.Diagnostic.GetLocation()
 	startLine, endLine := githubCommentLineRange(c)
+	side := githubCommentSide(loc)
 	r := &github.DraftReviewComment{
-		Path: github.Ptr(loc.GetPath()),
-		Side: github.Ptr("RIGHT"),
+		Path: github.Ptr(commentutil.CommentPath(c)),
+		Side: github.Ptr(side),
 		Body: github.Ptr(body),
 		Line: github.Ptr(endLine),
 	}
 	// GitHub API: Start line must precede the end line.
 	if startLine < endLine {
-		r.StartSide = github.Ptr("RIGHT")
+		r.StartSide = github.Ptr(side)
 		r.StartLine = github.Ptr(startLine)
 	}
 	return r
 }
 
+// githubCommentSide returns LEFT for locations in the old file (e.g. deleted
+// lines), otherwise RIGHT.
+func githubCommentSide(loc *rdf.Location) string {
+	if loc.GetOld() {
+		return "LEFT"
+	}
+	return "RIGHT"
+}
+
 func buildPullRequestFileComment(c *reviewdog.Comment, body string, sha string) *github.PullRequestComment {
 	return &github.PullRequestComment{
-		Path:        github.Ptr(c.Result.Diagnostic.GetLocation().GetPath()),
-		Side:        github.Ptr("RIGHT"),
+		Path:        github.Ptr(commentutil.CommentPath(c)),
+		Side:        github.Ptr(githubCommentSide(c.Result.Diagnostic.GetLocation())),
 		Body:        github.Ptr(body),
 		CommitID:    github.Ptr(sha),
 		SubjectType: github.Ptr("file"),
@@ -426,11 +527,15 @@ func listAllPullRequestsComments(ctx context.Context, cli *github.Client,
 
 func buildBody(c *reviewdog.Comment, baseURL string, gitRootPath string, fprint string, toolName string) string {
 	cbody := commentutil.MarkdownComment(c)
-	if c.Result.InDiffContext {
+	switch {
+	case c.Result.Diagnostic.GetLocation().GetOld():
+		// Suggestions and code snippets of the head commit are not applicable
+		// to the old file.
+	case c.Result.InDiffContext:
 		if suggestion := buildSuggestions(c); suggestion != "" {
 			cbody += "\n" + suggestion
 		}
-	} else {
+	default:
 		if c.Result.Diagnostic.GetLocation().GetRange().GetStart().GetLine() > 0 {
 			snippetURL := githubCodeSnippetURL(baseURL, gitRootPath, c.Result.Diagnostic.GetLocation())
 			cbody += "\n\n" + snippetURL
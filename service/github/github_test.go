@@ -21,6 +21,7 @@ import (
 	"github.com/reviewdog/reviewdog/filter"
 	"github.com/reviewdog/reviewdog/proto/rdf"
 	"github.com/reviewdog/reviewdog/service/commentutil"
+	"github.com/reviewdog/reviewdog/service/serviceutil"
 )
 
 const notokenSkipTestMes = "skipping test (requires actual Personal access tokens. export REVIEWDOG_TEST_GITHUB_API_TOKEN=<GitHub Personal Access Token>)"
@@ -1431,3 +1432,468 @@ func TestGitHubPullRequest_Post_NoPermission(t *testing.T) {
 		t.Errorf("GitHub post PullRequest comments API called %v times, want %d times", postCommentsAPICalled, want)
 	}
 }
+
+func TestGitHubPullRequest_Flush_resolveOutdatedThreads(t *testing.T) {
+	cwd, _ := os.Getwd()
+	defer os.Chdir(cwd)
+	moveToRootDir()
+	defer setupEnvs()()
+
+	newComment := func(line int32, msg string) *reviewdog.Comment {
+		return &reviewdog.Comment{
+			Result: &filter.FilteredDiagnostic{
+				Diagnostic: &rdf.Diagnostic{
+					Location: &rdf.Location{
+						Path:  "reviewdog.go",
+						Range: &rdf.Range{Start: &rdf.Position{Line: line}},
+					},
+					Message: msg,
+				},
+				InDiffFile:    true,
+				InDiffContext: true,
+			},
+		}
+	}
+	postedComment := func(id int64, c *reviewdog.Comment) *github.PullRequestComment {
+		fprint, err := serviceutil.Fingerprint(c.Result.Diagnostic)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &github.PullRequestComment{
+			ID:          github.Ptr(id),
+			Path:        github.Ptr("reviewdog.go"),
+			Line:        github.Ptr(int(c.Result.Diagnostic.GetLocation().GetRange().GetStart().GetLine())),
+			Body:        github.Ptr(commentutil.BodyPrefix + c.Result.Diagnostic.GetMessage() + "\n" + serviceutil.BuildMetaComment(fprint, "tool-name") + "\n"),
+			SubjectType: github.Ptr("line"),
+		}
+	}
+	reopened := newComment(2, "reported again")
+	open := newComment(3, "still open")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/o/r/pulls/14/comments", func(w http.ResponseWriter, r *http.Request) {
+		cs := []*github.PullRequestComment{
+			postedComment(1, newComment(1, "outdated")),
+			postedComment(2, reopened),
+			postedComment(3, open),
+			postedComment(4, newComment(4, "outdated and resolved")),
+			{ID: github.Ptr(int64(5)), InReplyTo: github.Ptr(int64(1)), Body: github.Ptr("reply")},
+		}
+		if err := json.NewEncoder(w).Encode(cs); err != nil {
+			t.Fatal(err)
+		}
+	})
+	mux.HandleFunc("/repos/o/r", func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewEncoder(w).Encode(&github.Repository{
+			HTMLURL: github.Ptr("https://test/repo/path"),
+		}); err != nil {
+			t.Fatal(err)
+		}
+	})
+	mux.HandleFunc("/repos/o/r/pulls/comments/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+	})
+	var mutations []string
+	mux.HandleFunc("/api/graphql", func(w http.ResponseWriter, r *http.Request) {
+		var req graphQLRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatal(err)
+		}
+		var resp string
+		switch {
+		case strings.HasPrefix(req.Query, "mutation"):
+			name, _, _ := strings.Cut(strings.TrimSpace(strings.SplitN(req.Query, "{", 3)[1]), "(")
+			mutations = append(mutations, name+" "+req.Variables["id"].(string))
+			resp = `{"data": {}}`
+		case req.Variables["after"] == nil:
+			resp = `{"data": {"repository": {"pullRequest": {"reviewThreads": {
+				"pageInfo": {"hasNextPage": true, "endCursor": "c1"},
+				"nodes": [
+					{"id": "T1", "isResolved": false, "comments": {"nodes": [{"databaseId": 1}]}},
+					{"id": "T2", "isResolved": true, "comments": {"nodes": [{"databaseId": 2}]}}
+				]}}}}}`
+		case req.Variables["after"] == "c1":
+			resp = `{"data": {"repository": {"pullRequest": {"reviewThreads": {
+				"pageInfo": {"hasNextPage": false},
+				"nodes": [
+					{"id": "T3", "isResolved": false, "comments": {"nodes": [{"databaseId": 3}]}},
+					{"id": "T4", "isResolved": true, "comments": {"nodes": [{"databaseId": 4}]}}
+				]}}}}}`
+		default:
+			t.Errorf("unexpected variables: %v", req.Variables)
+		}
+		w.Write([]byte(resp))
+	})
+	// Use GitHub Enterprise style URLs to test the GraphQL API endpoint.
+	mux.Handle("/api/v3/", http.StripPrefix("/api/v3", mux))
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	cli := github.NewClient(nil)
+	cli.BaseURL, _ = url.Parse(ts.URL + "/api/v3/")
+	g := NewGitHubPullRequest(cli, "o", "r", 14, "sha", "warning", "tool-name")
+	g.SetResolveOutdatedThreads(true)
+	for _, c := range []*reviewdog.Comment{reopened, open} {
+		if err := g.Post(context.Background(), c); err != nil {
+			t.Error(err)
+		}
+	}
+	if err := g.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"resolveReviewThread T1", "unresolveReviewThread T2"}
+	if diff := cmp.Diff(want, mutations); diff != "" {
+		t.Errorf("mutations diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestGraphQL_error(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data": null, "errors": [{"message": "a"}, {"message": "b"}]}`))
+	}))
+	defer ts.Close()
+	cli := github.NewClient(nil)
+	cli.BaseURL, _ = url.Parse(ts.URL + "/")
+	err := resolveReviewThread(context.Background(), cli, "T1", true)
+	if err == nil || err.Error() != "a; b" {
+		t.Errorf("got error %v, want a; b", err)
+	}
+}
+
+func TestBuildDraftReviewComment_old(t *testing.T) {
+	c := &reviewdog.Comment{
+		Result: &filter.FilteredDiagnostic{
+			Diagnostic: &rdf.Diagnostic{
+				Location: &rdf.Location{
+					Path: "reviewdog.go",
+					Range: &rdf.Range{
+						Start: &rdf.Position{Line: 14},
+						End:   &rdf.Position{Line: 15},
+					},
+					Old: true,
+				},
+				Message: "error handling is removed",
+				Suggestions: []*rdf.Suggestion{{
+					Range: &rdf.Range{Start: &rdf.Position{Line: 14}, End: &rdf.Position{Line: 15}},
+					Text:  "not applicable",
+				}},
+			},
+			InDiffFile:    true,
+			InDiffContext: true,
+		},
+	}
+	got := buildDraftReviewComment(c, buildBody(c, "https://test/repo/path/blob/sha", "", "xxx", "tool"))
+	want := &github.DraftReviewComment{
+		Path:      github.Ptr("reviewdog.go"),
+		Side:      github.Ptr("LEFT"),
+		StartSide: github.Ptr("LEFT"),
+		StartLine: github.Ptr(14),
+		Line:      github.Ptr(15),
+		Body: github.Ptr(commentutil.BodyPrefix + "error handling is removed\n" +
+			serviceutil.BuildMetaComment("xxx", "tool") + "\n"),
+	}
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("comment diff: (-want +got)\n%s", diff)
+	}
+}
+
+func TestGitHubPullRequest_Flush_reviewEvent(t *testing.T) {
+	cwd, _ := os.Getwd()
+	defer os.Chdir(cwd)
+	moveToRootDir()
+	defer setupEnvs()()
+
+	review := func(id int64, tool, state string) *github.PullRequestReview {
+		return &github.PullRequestReview{ID: github.Ptr(id), State: github.Ptr(state), Body: github.Ptr("body\n" + reviewMarker(tool))}
+	}
+	tests := []struct {
+		name          string
+		event         ReviewEvent
+		severity      rdf.Severity
+		reviews       []*github.PullRequestReview
+		wantEvent     string // empty if no review is submitted
+		wantDismissed []int64
+	}{
+		{
+			name:      "request changes",
+			event:     ReviewEventRequestChanges,
+			severity:  rdf.Severity_ERROR,
+			reviews:   []*github.PullRequestReview{review(1, "tool-name", "DISMISSED"), {ID: github.Ptr(int64(2)), State: github.Ptr("CHANGES_REQUESTED")}},
+			wantEvent: "REQUEST_CHANGES",
+		},
+		{
+			name:     "already requested changes",
+			event:    ReviewEventRequestChanges,
+			severity: rdf.Severity_ERROR,
+			reviews:  []*github.PullRequestReview{review(1, "tool-name", "CHANGES_REQUESTED"), review(2, "tool-name", "COMMENTED")},
+		},
+		{
+			name:          "dismiss",
+			event:         ReviewEventRequestChanges,
+			severity:      rdf.Severity_WARNING,
+			reviews:       []*github.PullRequestReview{review(1, "tool-name", "CHANGES_REQUESTED"), review(2, "other", "CHANGES_REQUESTED")},
+			wantDismissed: []int64{1},
+		},
+		{
+			name:          "approve",
+			event:         ReviewEventApprove,
+			severity:      rdf.Severity_WARNING,
+			reviews:       []*github.PullRequestReview{review(1, "tool-name", "CHANGES_REQUESTED")},
+			wantEvent:     "APPROVE",
+			wantDismissed: []int64{1},
+		},
+		{
+			name:          "approved before requesting changes",
+			event:         ReviewEventApprove,
+			severity:      rdf.Severity_WARNING,
+			reviews:       []*github.PullRequestReview{review(1, "other", "APPROVED"), review(2, "tool-name", "CHANGES_REQUESTED")},
+			wantDismissed: []int64{2},
+		},
+		{
+			name:          "other tool requested changes",
+			event:         ReviewEventApprove,
+			severity:      rdf.Severity_WARNING,
+			reviews:       []*github.PullRequestReview{review(1, "tool-name", "CHANGES_REQUESTED"), review(2, "other", "CHANGES_REQUESTED")},
+			wantDismissed: []int64{1},
+		},
+		{
+			name:     "already approved",
+			event:    ReviewEventApprove,
+			severity: rdf.Severity_WARNING,
+			reviews:  []*github.PullRequestReview{review(1, "other", "APPROVED")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotEvent := ""
+			var gotDismissed []int64
+			mux := http.NewServeMux()
+			mux.HandleFunc("/repos/o/r/pulls/14/comments", func(w http.ResponseWriter, r *http.Request) {
+				if err := json.NewEncoder(w).Encode([]*github.PullRequestComment{}); err != nil {
+					t.Fatal(err)
+				}
+			})
+			mux.HandleFunc("/repos/o/r", func(w http.ResponseWriter, r *http.Request) {
+				if err := json.NewEncoder(w).Encode(&github.Repository{
+					HTMLURL: github.Ptr("https://test/repo/path"),
+				}); err != nil {
+					t.Fatal(err)
+				}
+			})
+			mux.HandleFunc("/repos/o/r/pulls/14/reviews", func(w http.ResponseWriter, r *http.Request) {
+				switch r.Method {
+				case http.MethodGet:
+					if err := json.NewEncoder(w).Encode(tt.reviews); err != nil {
+						t.Fatal(err)
+					}
+				case http.MethodPost:
+					var req github.PullRequestReviewRequest
+					if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+						t.Error(err)
+					}
+					gotEvent = req.GetEvent()
+					if !strings.Contains(req.GetBody(), reviewMarker("tool-name")) {
+						t.Errorf("review body doesn't have the marker: %q", req.GetBody())
+					}
+				}
+			})
+			mux.HandleFunc("/repos/o/r/pulls/14/reviews/{id}/dismissals", func(w http.ResponseWriter, r *http.Request) {
+				var id int64
+				if err := json.Unmarshal([]byte(r.PathValue("id")), &id); err != nil {
+					t.Fatal(err)
+				}
+				gotDismissed = append(gotDismissed, id)
+			})
+			ts := httptest.NewServer(mux)
+			defer ts.Close()
+
+			cli := github.NewClient(nil)
+			cli.BaseURL, _ = url.Parse(ts.URL + "/")
+			g := NewGitHubPullRequest(cli, "o", "r", 14, "sha", "warning", "tool-name")
+			g.SetReviewEvent(tt.event, reviewdog.FailLevelError)
+			// The result is outside diff files so that it's not posted as a review
+			// comment.
+			if err := g.Post(context.Background(), &reviewdog.Comment{
+				Result: &filter.FilteredDiagnostic{
+					Diagnostic: &rdf.Diagnostic{
+						Location: &rdf.Location{Path: "reviewdog.go"},
+						Message:  "result",
+						Severity: tt.severity,
+					},
+				},
+			}); err != nil {
+				t.Fatal(err)
+			}
+			if err := g.Flush(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+			if gotEvent != tt.wantEvent {
+				t.Errorf("submitted review event = %q, want %q", gotEvent, tt.wantEvent)
+			}
+			if diff := cmp.Diff(tt.wantDismissed, gotDismissed); diff != "" {
+				t.Errorf("dismissed reviews diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGitHubPullRequest_Flush_reviewEventUnprocessable(t *testing.T) {
+	cwd, _ := os.Getwd()
+	defer os.Chdir(cwd)
+	moveToRootDir()
+	defer setupEnvs()()
+
+	var gotEvents []string
+	gotComments := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/o/r/pulls/14/comments", func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewEncoder(w).Encode([]*github.PullRequestComment{}); err != nil {
+			t.Fatal(err)
+		}
+	})
+	mux.HandleFunc("/repos/o/r", func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewEncoder(w).Encode(&github.Repository{
+			HTMLURL: github.Ptr("https://test/repo/path"),
+		}); err != nil {
+			t.Fatal(err)
+		}
+	})
+	mux.HandleFunc("/repos/o/r/pulls/14/reviews", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			if err := json.NewEncoder(w).Encode([]*github.PullRequestReview{}); err != nil {
+				t.Fatal(err)
+			}
+		case http.MethodPost:
+			var req github.PullRequestReviewRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Error(err)
+			}
+			gotEvents = append(gotEvents, req.GetEvent())
+			if req.GetEvent() != "COMMENT" {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				return
+			}
+			gotComments = len(req.Comments)
+		}
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	cli := github.NewClient(nil)
+	cli.BaseURL, _ = url.Parse(ts.URL + "/")
+	g := NewGitHubPullRequest(cli, "o", "r", 14, "sha", "warning", "tool-name")
+	g.SetReviewEvent(ReviewEventRequestChanges, reviewdog.FailLevelError)
+	if err := g.Post(context.Background(), &reviewdog.Comment{
+		Result: &filter.FilteredDiagnostic{
+			Diagnostic: &rdf.Diagnostic{
+				Location: &rdf.Location{
+					Path:  "reviewdog.go",
+					Range: &rdf.Range{Start: &rdf.Position{Line: 1}},
+				},
+				Message:  "result",
+				Severity: rdf.Severity_ERROR,
+			},
+			InDiffFile:    true,
+			InDiffContext: true,
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"REQUEST_CHANGES", "COMMENT"}, gotEvents); diff != "" {
+		t.Errorf("submitted review events diff (-want +got):\n%s", diff)
+	}
+	if gotComments != 1 {
+		t.Errorf("got %d review comments, want 1", gotComments)
+	}
+}
+
+func TestGitHubPullRequest_Flush_oldRenamed(t *testing.T) {
+	cwd, _ := os.Getwd()
+	defer os.Chdir(cwd)
+	moveToRootDir()
+	defer setupEnvs()()
+
+	// Results on deleted lines of old.go which is renamed to new.go.
+	oldResult := func(line int32) *reviewdog.Comment {
+		return &reviewdog.Comment{
+			Result: &filter.FilteredDiagnostic{
+				Diagnostic: &rdf.Diagnostic{
+					Location: &rdf.Location{
+						Path:  "old.go",
+						Range: &rdf.Range{Start: &rdf.Position{Line: line}},
+						Old:   true,
+					},
+					Message: "deleted",
+				},
+				InDiffFile:    true,
+				InDiffContext: true,
+				OldPath:       "old.go",
+				OldLine:       int(line),
+				NewPath:       "new.go",
+			},
+		}
+	}
+	posted := oldResult(1)
+	fprint, err := serviceutil.Fingerprint(posted.Result.Diagnostic)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var gotComments []*github.DraftReviewComment
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/o/r/pulls/14/comments", func(w http.ResponseWriter, r *http.Request) {
+		// GitHub returns the path of the pull request file for comments on
+		// the old file.
+		if err := json.NewEncoder(w).Encode([]*github.PullRequestComment{{
+			ID:   github.Ptr(int64(1)),
+			Path: github.Ptr("new.go"),
+			Line: github.Ptr(1),
+			Side: github.Ptr("LEFT"),
+			Body: github.Ptr(serviceutil.BuildMetaComment(fprint, "tool-name")),
+		}}); err != nil {
+			t.Fatal(err)
+		}
+	})
+	mux.HandleFunc("/repos/o/r/pulls/comments/1", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("posted comment should not be outdated: %s %s", r.Method, r.URL.Path)
+	})
+	mux.HandleFunc("/repos/o/r", func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewEncoder(w).Encode(&github.Repository{
+			HTMLURL: github.Ptr("https://test/repo/path"),
+		}); err != nil {
+			t.Fatal(err)
+		}
+	})
+	mux.HandleFunc("/repos/o/r/pulls/14/reviews", func(w http.ResponseWriter, r *http.Request) {
+		var req github.PullRequestReviewRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+		}
+		gotComments = append(gotComments, req.Comments...)
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	cli := github.NewClient(nil)
+	cli.BaseURL, _ = url.Parse(ts.URL + "/")
+	g := NewGitHubPullRequest(cli, "o", "r", 14, "sha", "warning", "tool-name")
+	for _, c := range []*reviewdog.Comment{posted, oldResult(2)} {
+		if err := g.Post(context.Background(), c); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := g.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(gotComments) != 1 {
+		t.Fatalf("got %d review comments, want 1", len(gotComments))
+	}
+	got := gotComments[0]
+	if got.GetPath() != "new.go" || got.GetSide() != "LEFT" || got.GetLine() != 2 {
+		t.Errorf("got review comment on %s:%d (%s), want new.go:2 (LEFT)", got.GetPath(), got.GetLine(), got.GetSide())
+	}
+}
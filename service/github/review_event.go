@@ -0,0 +1,153 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v74/github"
+
+	"github.com/reviewdog/reviewdog"
+)
+
+// ReviewEvent represents how PullRequest submits reviews depending on whether
+// results meet the fail level.
+type ReviewEvent int
+
+const (
+	// ReviewEventComment always submits COMMENT reviews.
+	ReviewEventComment ReviewEvent = iota
+	// ReviewEventRequestChanges submits REQUEST_CHANGES reviews if results meet
+	// the fail level. Otherwise, it dismisses the REQUEST_CHANGES reviews which
+	// it submitted before.
+	ReviewEventRequestChanges
+	// ReviewEventApprove is same as ReviewEventRequestChanges, but it approves
+	// the pull request as well if results of the tool don't meet the fail level
+	// and no other tools request changes. It's decided per tool when the tool's
+	// results are flushed, so in project config based run, a later runner can
+	// request changes after an earlier runner approves the pull request.
+	ReviewEventApprove
+)
+
+// String implements the flag.Value interface
+func (e *ReviewEvent) String() string {
+	names := [...]string{
+		"comment",
+		"request-changes",
+		"approve",
+	}
+	if *e < ReviewEventComment || *e > ReviewEventApprove {
+		return "Unknown review event"
+	}
+	return names[*e]
+}
+
+// Set implements the flag.Value interface
+func (e *ReviewEvent) Set(value string) error {
+	switch value {
+	case "comment", "":
+		*e = ReviewEventComment
+	case "request-changes":
+		*e = ReviewEventRequestChanges
+	case "approve":
+		*e = ReviewEventApprove
+	default:
+		return fmt.Errorf("invalid review event name: %s", value)
+	}
+	return nil
+}
+
+// SetReviewEvent sets the review event and the fail level which decides the
+// event.
+func (g *PullRequest) SetReviewEvent(event ReviewEvent, failLevel reviewdog.FailLevel) {
+	g.reviewEvent = event
+	g.failLevel = failLevel
+}
+
+const reviewMarkerPrefix = "<!-- __reviewdog_review__:"
+
+// reviewMarker returns a hidden marker in the body of REQUEST_CHANGES and
+// APPROVE reviews to find reviews which reviewdog submitted for the tool.
+func reviewMarker(toolName string) string {
+	return reviewMarkerPrefix + base64.StdEncoding.EncodeToString([]byte(toolName)) + " -->"
+}
+
+// decideReviewEvent returns the event and the body of the review for the
+// comments of the current tool. needsReview is true if reviewdog should
+// submit the review even if it has no review comments.
+//
+// It dismisses REQUEST_CHANGES reviews of the tool if the comments don't meet
+// the fail level.
+func (g *PullRequest) decideReviewEvent(ctx context.Context, comments []*reviewdog.Comment) (event, body string, needsReview bool, err error) {
+	failures := 0
+	for _, c := range comments {
+		if g.failLevel.ShouldFail(c.Result.Diagnostic.GetSeverity()) {
+			failures++
+		}
+	}
+	reviews, err := listAllReviews(ctx, g.cli, g.owner, g.repo, g.pr)
+	if err != nil {
+		return "", "", false, fmt.Errorf("failed to list reviews: %w", err)
+	}
+	marker := reviewMarker(g.toolName)
+	// REQUEST_CHANGES and APPROVE reviews by reviewdog in chronological order.
+	var decisive []*github.PullRequestReview
+	for _, r := range reviews {
+		state := r.GetState()
+		if strings.Contains(r.GetBody(), reviewMarkerPrefix) && (state == "CHANGES_REQUESTED" || state == "APPROVED") {
+			decisive = append(decisive, r)
+		}
+	}
+	ownState := ""
+	for _, r := range decisive {
+		if strings.Contains(r.GetBody(), marker) {
+			ownState = r.GetState()
+		}
+	}
+
+	if failures > 0 {
+		body = fmt.Sprintf("reviewdog [%s] found %d result(s) with severity greater than or equal to the fail level (%s).\n%s",
+			g.toolName, failures, g.failLevel.String(), marker)
+		return "REQUEST_CHANGES", body, ownState != "CHANGES_REQUESTED", nil
+	}
+
+	latestState := ""
+	othersRequestChanges := false
+	for _, r := range decisive {
+		if r.GetState() == "CHANGES_REQUESTED" && strings.Contains(r.GetBody(), marker) {
+			msg := fmt.Sprintf("reviewdog [%s] no longer finds results with severity greater than or equal to the fail level (%s).",
+				g.toolName, g.failLevel.String())
+			if _, _, err := g.cli.PullRequests.DismissReview(ctx, g.owner, g.repo, g.pr, r.GetID(),
+				&github.PullRequestReviewDismissalRequest{Message: github.Ptr(msg)}); err != nil {
+				return "", "", false, fmt.Errorf("failed to dismiss review (id=%d): %w", r.GetID(), err)
+			}
+			continue
+		}
+		latestState = r.GetState()
+		othersRequestChanges = othersRequestChanges || r.GetState() == "CHANGES_REQUESTED"
+	}
+
+	if g.reviewEvent == ReviewEventApprove && !othersRequestChanges && latestState != "APPROVED" {
+		body = fmt.Sprintf("reviewdog [%s] found no results with severity greater than or equal to the fail level (%s).\n%s",
+			g.toolName, g.failLevel.String(), marker)
+		return "APPROVE", body, true, nil
+	}
+	return "COMMENT", "", false, nil
+}
+
+func listAllReviews(ctx context.Context, cli *github.Client, owner, repo string, pr int) ([]*github.PullRequestReview, error) {
+	opts := &github.ListOptions{PerPage: 100}
+	var all []*github.PullRequestReview
+	for {
+		reviews, resp, err := cli.PullRequests.ListReviews(ctx, owner, repo, pr, opts)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, reviews...)
+		if resp.NextPage == 0 {
+			return all, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
@@ -0,0 +1,135 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/v74/github"
+)
+
+// graphQLEndpoint is the GraphQL API endpoint relative to the REST API base
+// URL. It's https://api.github.com/graphql for GitHub.com and
+// https://<host>/api/graphql for GitHub Enterprise (base URL is
+// https://<host>/api/v3/).
+const graphQLEndpoint = "../graphql"
+
+// reviewThread is a review thread of a pull request.
+type reviewThread struct {
+	ID         string
+	IsResolved bool
+}
+
+type graphQLRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables,omitempty"`
+}
+
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// graphQL calls GitHub GraphQL API and stores the data of the response in the
+// value pointed to by data.
+// https://docs.github.com/en/graphql/guides/forming-calls-with-graphql
+func graphQL(ctx context.Context, cli *github.Client, query string, vars map[string]any, data any) error {
+	req, err := cli.NewRequest(http.MethodPost, graphQLEndpoint, &graphQLRequest{Query: query, Variables: vars})
+	if err != nil {
+		return err
+	}
+	var resp graphQLResponse
+	if _, err := cli.Do(ctx, req, &resp); err != nil {
+		return err
+	}
+	if len(resp.Errors) > 0 {
+		msgs := make([]string, 0, len(resp.Errors))
+		for _, e := range resp.Errors {
+			msgs = append(msgs, e.Message)
+		}
+		return errors.New(strings.Join(msgs, "; "))
+	}
+	if data == nil {
+		return nil
+	}
+	return json.Unmarshal(resp.Data, data)
+}
+
+const reviewThreadsQuery = `query($owner: String!, $repo: String!, $pr: Int!, $after: String) {
+  repository(owner: $owner, name: $repo) {
+    pullRequest(number: $pr) {
+      reviewThreads(first: 100, after: $after) {
+        pageInfo { hasNextPage endCursor }
+        nodes {
+          id
+          isResolved
+          comments(first: 1) { nodes { databaseId } }
+        }
+      }
+    }
+  }
+}`
+
+// listReviewThreads returns review threads of the pull request keyed by ID of
+// the first comment of the thread.
+func listReviewThreads(ctx context.Context, cli *github.Client, owner, repo string, pr int) (map[int64]*reviewThread, error) {
+	threads := make(map[int64]*reviewThread)
+	vars := map[string]any{"owner": owner, "repo": repo, "pr": pr}
+	for {
+		var data struct {
+			Repository struct {
+				PullRequest struct {
+					ReviewThreads struct {
+						PageInfo struct {
+							HasNextPage bool   `json:"hasNextPage"`
+							EndCursor   string `json:"endCursor"`
+						} `json:"pageInfo"`
+						Nodes []struct {
+							ID         string `json:"id"`
+							IsResolved bool   `json:"isResolved"`
+							Comments   struct {
+								Nodes []struct {
+									DatabaseID int64 `json:"databaseId"`
+								} `json:"nodes"`
+							} `json:"comments"`
+						} `json:"nodes"`
+					} `json:"reviewThreads"`
+				} `json:"pullRequest"`
+			} `json:"repository"`
+		}
+		if err := graphQL(ctx, cli, reviewThreadsQuery, vars, &data); err != nil {
+			return nil, fmt.Errorf("failed to list review threads: %w", err)
+		}
+		rts := data.Repository.PullRequest.ReviewThreads
+		for _, n := range rts.Nodes {
+			if len(n.Comments.Nodes) == 0 {
+				continue
+			}
+			threads[n.Comments.Nodes[0].DatabaseID] = &reviewThread{ID: n.ID, IsResolved: n.IsResolved}
+		}
+		if !rts.PageInfo.HasNextPage {
+			return threads, nil
+		}
+		vars["after"] = rts.PageInfo.EndCursor
+	}
+}
+
+const (
+	resolveReviewThreadMutation   = `mutation($id: ID!) { resolveReviewThread(input: {threadId: $id}) { thread { id } } }`
+	unresolveReviewThreadMutation = `mutation($id: ID!) { unresolveReviewThread(input: {threadId: $id}) { thread { id } } }`
+)
+
+// resolveReviewThread resolves the review thread. It unresolves the thread if
+// resolve is false.
+func resolveReviewThread(ctx context.Context, cli *github.Client, id string, resolve bool) error {
+	mutation := resolveReviewThreadMutation
+	if !resolve {
+		mutation = unresolveReviewThreadMutation
+	}
+	return graphQL(ctx, cli, mutation, map[string]any{"id": id}, nil)
+}
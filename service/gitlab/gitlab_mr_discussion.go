@@ -58,6 +58,9 @@ func (g *MergeRequestDiscussionCommenter) Post(_ context.Context, c *reviewdog.C
 
 func (*MergeRequestDiscussionCommenter) ShouldPrependGitRelDir() bool { return true }
 
+// SupportsOldLines returns true as discussions can be posted on deleted lines.
+func (*MergeRequestDiscussionCommenter) SupportsOldLines() bool { return true }
+
 // Flush posts comments which has not been posted yet.
 func (g *MergeRequestDiscussionCommenter) Flush(ctx context.Context) error {
 	g.muComments.Lock()
@@ -122,8 +125,11 @@ func (g *MergeRequestDiscussionCommenter) postCommentsForEach(ctx context.Contex
 				HeadSHA:      gitlab.Ptr(g.sha),
 				BaseSHA:      gitlab.Ptr(targetBranch.Commit.ID),
 				PositionType: gitlab.Ptr("text"),
-				NewPath:      gitlab.Ptr(loc.GetPath()),
-				NewLine:      gitlab.Ptr(int64(lnum)),
+				NewPath:      gitlab.Ptr(commentutil.CommentPath(c)),
+			}
+			// Only old_line is set for deleted lines.
+			if !loc.GetOld() {
+				pos.NewLine = gitlab.Ptr(int64(lnum))
 			}
 			if c.Result.OldPath != "" && c.Result.OldLine != 0 {
 				pos.OldPath = gitlab.Ptr(c.Result.OldPath)
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"hash/fnv"
 	"log"
+	"sort"
 	"strings"
 
 	"github.com/reviewdog/reviewdog/proto/metacomment"
@@ -61,8 +62,25 @@ func BuildMetaComment(fprint string, toolName string) string {
 }
 
 // Fingerprint calculates a hash for the given diagnostic message.
+//
+// If the diagnostic has fingerprints reported by the tool (e.g. SARIF
+// partialFingerprints), it uses them along with the path instead of the whole
+// diagnostic so that the identity is stable even if the message or the
+// location changes.
 func Fingerprint(d *rdf.Diagnostic) (string, error) {
 	h := fnv.New64a()
+	if fps := d.GetFingerprints(); len(fps) > 0 {
+		keys := make([]string, 0, len(fps))
+		for k := range fps {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		fmt.Fprintf(h, "%s\n", d.GetLocation().GetPath())
+		for _, k := range keys {
+			fmt.Fprintf(h, "%s=%s\n", k, fps[k])
+		}
+		return fmt.Sprintf("%x", h.Sum64()), nil
+	}
 	// Ideally, we should not use proto.Marshal since Proto Serialization Is Not
 	// Canonical.
 	// https://protobuf.dev/programming-guides/serialization-not-canonical/
@@ -35,6 +35,42 @@ func TestFingerprint(t *testing.T) {
 	}
 }
 
+func TestFingerprint_fingerprints(t *testing.T) {
+	d := func(msg string, line int32) *rdf.Diagnostic {
+		return &rdf.Diagnostic{
+			Message: msg,
+			Location: &rdf.Location{
+				Path:  "a.go",
+				Range: &rdf.Range{Start: &rdf.Position{Line: line}},
+			},
+			Fingerprints: map[string]string{
+				"primaryLocationLineHash": "39fa2ee980eb94b0:1",
+				"stable/v1":               "abc",
+			},
+		}
+	}
+	f1, err := Fingerprint(d("message 1", 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f2, err := Fingerprint(d("message 2", 10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f1 != f2 {
+		t.Errorf("Fingerprint() should be same for diagnostics with same fingerprints: %q != %q", f1, f2)
+	}
+	other := d("message 1", 1)
+	other.Fingerprints["stable/v1"] = "xyz"
+	f3, err := Fingerprint(other)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f1 == f3 {
+		t.Errorf("Fingerprint() should be different for diagnostics with different fingerprints: %q", f1)
+	}
+}
+
 func TestBuildMetaComment(t *testing.T) {
 	fprint := "d102792a57188ea4"
 	toolName := "testdog"
@@ -0,0 +1,132 @@
+package teamcityutils
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/reviewdog/reviewdog"
+	"github.com/reviewdog/reviewdog/proto/rdf"
+)
+
+var _ reviewdog.CommentService = &TeamCityInspectionWriter{}
+
+// TeamCityInspectionWriter reports results via inspectionType and inspection
+// service messages so that TeamCity shows them in Inspections tab.
+// https://www.jetbrains.com/help/teamcity/service-messages.html#Reporting+Inspections
+type TeamCityInspectionWriter struct {
+	w     io.Writer
+	level string
+	// reported inspection type IDs.
+	types map[string]bool
+}
+
+// NewTeamCityInspectionWriter returns new TeamCityInspectionWriter.
+func NewTeamCityInspectionWriter(w io.Writer, level string) *TeamCityInspectionWriter {
+	return &TeamCityInspectionWriter{w: w, level: level, types: make(map[string]bool)}
+}
+
+func (tw *TeamCityInspectionWriter) Post(_ context.Context, c *reviewdog.Comment) error {
+	d := c.Result.Diagnostic
+	toolName := d.GetSource().GetName()
+	if toolName == "" {
+		toolName = c.ToolName
+	}
+	typeID := InspectionTypeID(toolName, d.GetCode().GetValue())
+	if !tw.types[typeID] {
+		tw.types[typeID] = true
+		name := d.GetCode().GetValue()
+		if name == "" {
+			name = toolName
+		}
+		description := fmt.Sprintf("%s reported by %s", name, toolName)
+		if url := d.GetCode().GetUrl(); url != "" {
+			description += ": " + url
+		}
+		if _, err := fmt.Fprintln(tw.w, serviceMessage("inspectionType",
+			"id", typeID,
+			"name", name,
+			"description", description,
+			"category", toolName,
+		)); err != nil {
+			return err
+		}
+	}
+	attrs := []string{"typeId", typeID, "message", d.GetMessage()}
+	if path := d.GetLocation().GetPath(); path != "" {
+		attrs = append(attrs, "file", path)
+		if line := d.GetLocation().GetRange().GetStart().GetLine(); line > 0 {
+			attrs = append(attrs, "line", fmt.Sprint(line))
+		}
+	}
+	if severity := inspectionSeverity(d.GetSeverity(), tw.level); severity != "" {
+		attrs = append(attrs, "SEVERITY", severity)
+	}
+	_, err := fmt.Fprintln(tw.w, serviceMessage("inspection", attrs...))
+	return err
+}
+
+func (*TeamCityInspectionWriter) ShouldPrependGitRelDir() bool { return true }
+
+// InspectionTypeID returns inspection type ID of the rule code of the tool.
+func InspectionTypeID(toolName, code string) string {
+	if code == "" {
+		return toolName
+	}
+	return toolName + "/" + code
+}
+
+// inspectionSeverity returns SEVERITY attribute of the severity. defaultLevel
+// is used if the severity is unknown.
+func inspectionSeverity(s rdf.Severity, defaultLevel string) string {
+	switch s {
+	case rdf.Severity_ERROR:
+		return "ERROR"
+	case rdf.Severity_WARNING:
+		return "WARNING"
+	case rdf.Severity_INFO:
+		return "INFO"
+	}
+	switch defaultLevel {
+	case "error":
+		return "ERROR"
+	case "warning":
+		return "WARNING"
+	case "info":
+		return "INFO"
+	default:
+		return ""
+	}
+}
+
+// serviceMessage returns a service message with attributes of name-value
+// pairs.
+func serviceMessage(name string, attrs ...string) string {
+	var b strings.Builder
+	b.WriteString("##teamcity[")
+	b.WriteString(name)
+	for i := 0; i+1 < len(attrs); i += 2 {
+		fmt.Fprintf(&b, " %s='%s'", attrs[i], escape(attrs[i+1]))
+	}
+	b.WriteString("]")
+	return b.String()
+}
+
+var escaper = strings.NewReplacer(
+	"|", "||",
+	"'", "|'",
+	"\n", "|n",
+	"\r", "|r",
+	"[", "|[",
+	"]", "|]",
+	"\u0085", "|x",
+	"\u2028", "|l",
+	"\u2029", "|p",
+)
+
+// escape escapes a value of service messages.
+// https://www.jetbrains.com/help/teamcity/service-messages.html#Escaped+Values
+func escape(s string) string {
+	return escaper.Replace(s)
+}
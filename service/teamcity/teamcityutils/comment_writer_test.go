@@ -0,0 +1,81 @@
+package teamcityutils
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/reviewdog/reviewdog"
+	"github.com/reviewdog/reviewdog/filter"
+	"github.com/reviewdog/reviewdog/parser"
+	"github.com/reviewdog/reviewdog/proto/rdf"
+)
+
+func TestTeamCityInspectionWriter(t *testing.T) {
+	comments := []*reviewdog.Comment{
+		{
+			Result: &filter.FilteredDiagnostic{Diagnostic: &rdf.Diagnostic{
+				Location: &rdf.Location{
+					Path:  "a.js",
+					Range: &rdf.Range{Start: &rdf.Position{Line: 14, Column: 3}},
+				},
+				Message:  "don't use [x]\nsecond line | end",
+				Severity: rdf.Severity_ERROR,
+				Code:     &rdf.Code{Value: "semi", Url: "https://eslint.org/docs/rules/semi"},
+			}},
+			ToolName: "eslint",
+		},
+		{
+			Result: &filter.FilteredDiagnostic{Diagnostic: &rdf.Diagnostic{
+				Location: &rdf.Location{Path: "b.js", Range: &rdf.Range{Start: &rdf.Position{Line: 1}}},
+				Message:  "again",
+				Severity: rdf.Severity_INFO,
+				Code:     &rdf.Code{Value: "semi"},
+			}},
+			ToolName: "eslint",
+		},
+		{
+			Result: &filter.FilteredDiagnostic{Diagnostic: &rdf.Diagnostic{
+				Location: &rdf.Location{Path: "c.go"},
+				Message:  "no rule",
+			}},
+			ToolName: "golint",
+		},
+	}
+	buf := new(bytes.Buffer)
+	tw := NewTeamCityInspectionWriter(buf, "warning")
+	for _, c := range comments {
+		if err := tw.Post(context.Background(), c); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := `##teamcity[inspectionType id='eslint/semi' name='semi' description='semi reported by eslint: https://eslint.org/docs/rules/semi' category='eslint']
+##teamcity[inspection typeId='eslint/semi' message='don|'t use |[x|]|nsecond line || end' file='a.js' line='14' SEVERITY='ERROR']
+##teamcity[inspection typeId='eslint/semi' message='again' file='b.js' line='1' SEVERITY='INFO']
+##teamcity[inspectionType id='golint' name='golint' description='golint reported by golint' category='golint']
+##teamcity[inspection typeId='golint' message='no rule' file='c.go' SEVERITY='WARNING']
+`
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("result has diff (-want +got):\n%s", diff)
+	}
+
+	// Output should be parsed back by TeamCityInspectionParser.
+	ds, err := parser.NewTeamCityInspectionParser().Parse(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, d := range ds {
+		got = append(got, d.GetLocation().GetPath()+": "+d.GetMessage())
+	}
+	wantParsed := []string{
+		"a.js: don't use [x]\nsecond line | end",
+		"b.js: again",
+		"c.go: no rule",
+	}
+	if diff := cmp.Diff(wantParsed, got); diff != "" {
+		t.Errorf("parsed result has diff (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,302 @@
+// Package webhook provides a CommentService which posts results as JSON
+// documents to a webhook URL.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/reviewdog/reviewdog"
+	"github.com/reviewdog/reviewdog/cienv"
+	"github.com/reviewdog/reviewdog/commands"
+	"github.com/reviewdog/reviewdog/proto/rdf"
+)
+
+// SignatureHeader is the header of HMAC-SHA256 signature of payloads in
+// "sha256=<hex digest>" format.
+const SignatureHeader = "X-Reviewdog-Signature-256"
+
+var _ reviewdog.BulkCommentService = &Webhook{}
+var _ reviewdog.NamedCommentService = &Webhook{}
+
+// Webhook posts results to the URL as JSON documents (Payload) when Flush() is
+// called. Wrap it with reviewdog.NewReportCommentService to post results of all
+// runners at once in project config based run.
+type Webhook struct {
+	cli   *http.Client
+	url   string
+	build *cienv.BuildInfo
+	opt   *Option
+
+	comments []*reviewdog.Comment
+	tools    []*tool
+}
+
+type tool struct {
+	name  string
+	level string
+}
+
+// Option is options of Webhook.
+type Option struct {
+	// Secret is a key of HMAC-SHA256 signature of payloads. Payloads are not
+	// signed if it's empty.
+	Secret string
+	// Header is additional headers of requests.
+	Header http.Header
+	// BatchSize is the max number of results in a payload. All results are
+	// posted in a payload if it's 0.
+	BatchSize int
+	// MaxRetries is the max number of retries on network errors and 429 or 5xx
+	// responses.
+	MaxRetries int
+	// RetryWait is the wait before the first retry. It's doubled for each
+	// retry.
+	RetryWait time.Duration
+}
+
+// Payload is a JSON document which Webhook posts.
+type Payload struct {
+	Build   *Build   `json:"build,omitempty"`
+	Batch   Batch    `json:"batch"`
+	Summary *Summary `json:"summary"`
+	Tools   []*Tool  `json:"tools"`
+}
+
+// Build is build info of the payload.
+type Build struct {
+	Owner       string `json:"owner,omitempty"`
+	Repo        string `json:"repo,omitempty"`
+	SHA         string `json:"sha,omitempty"`
+	PullRequest int    `json:"pull_request,omitempty"`
+	Branch      string `json:"branch,omitempty"`
+}
+
+// Batch represents the position of the payload in all payloads of the run.
+// Index is 1-based.
+type Batch struct {
+	Index int `json:"index"`
+	Total int `json:"total"`
+}
+
+// Summary is counts of all results of the run.
+type Summary struct {
+	Total      int            `json:"total"`
+	ByTool     map[string]int `json:"by_tool"`
+	BySeverity map[string]int `json:"by_severity"`
+}
+
+// Tool has results of a tool (runner) in the payload. Results are
+// Diagnostics in rdjson format.
+type Tool struct {
+	Name    string            `json:"name"`
+	Level   string            `json:"level,omitempty"`
+	Results []json.RawMessage `json:"results"`
+}
+
+// NewWebhook returns a new Webhook. build can be nil.
+func NewWebhook(cli *http.Client, url string, build *cienv.BuildInfo, opt *Option) *Webhook {
+	if opt == nil {
+		opt = &Option{}
+	}
+	return &Webhook{cli: cli, url: url, build: build, opt: opt}
+}
+
+func (wh *Webhook) Post(_ context.Context, c *reviewdog.Comment) error {
+	wh.comments = append(wh.comments, c)
+	return nil
+}
+
+func (*Webhook) ShouldPrependGitRelDir() bool { return true }
+
+// SetTool registers the tool so that payloads have the tool even if it has no
+// findings.
+func (wh *Webhook) SetTool(toolName string, level string) {
+	if toolName == "" {
+		return
+	}
+	for _, t := range wh.tools {
+		if t.name == toolName {
+			t.level = level
+			return
+		}
+	}
+	wh.tools = append(wh.tools, &tool{name: toolName, level: level})
+}
+
+// Flush posts all results.
+func (wh *Webhook) Flush(ctx context.Context) error {
+	payloads, err := wh.payloads()
+	if err != nil {
+		return err
+	}
+	wh.comments = nil
+	for _, p := range payloads {
+		if err := wh.send(ctx, p); err != nil {
+			return fmt.Errorf("webhook: batch %d/%d: %w", p.Batch.Index, p.Batch.Total, err)
+		}
+	}
+	return nil
+}
+
+func (wh *Webhook) payloads() ([]*Payload, error) {
+	var build *Build
+	if wh.build != nil {
+		build = &Build{
+			Owner:       wh.build.Owner,
+			Repo:        wh.build.Repo,
+			SHA:         wh.build.SHA,
+			PullRequest: wh.build.PullRequest,
+			Branch:      wh.build.Branch,
+		}
+	}
+	summary := &Summary{
+		Total:      len(wh.comments),
+		ByTool:     make(map[string]int),
+		BySeverity: make(map[string]int),
+	}
+	for _, t := range wh.tools {
+		summary.ByTool[t.name] = 0
+	}
+	for _, c := range wh.comments {
+		summary.ByTool[c.ToolName]++
+		summary.BySeverity[severity(c.Result.Diagnostic.GetSeverity())]++
+	}
+
+	batches := [][]*reviewdog.Comment{wh.comments}
+	if size := wh.opt.BatchSize; size > 0 && len(wh.comments) > size {
+		batches = nil
+		for cs := range slices.Chunk(wh.comments, size) {
+			batches = append(batches, cs)
+		}
+	}
+	payloads := make([]*Payload, 0, len(batches))
+	for i, cs := range batches {
+		p := &Payload{
+			Build:   build,
+			Batch:   Batch{Index: i + 1, Total: len(batches)},
+			Summary: summary,
+			Tools:   make([]*Tool, 0),
+		}
+		tools := make(map[string]*Tool)
+		addTool := func(name, level string) *Tool {
+			if t, ok := tools[name]; ok {
+				return t
+			}
+			t := &Tool{Name: name, Level: level, Results: make([]json.RawMessage, 0)}
+			tools[name] = t
+			p.Tools = append(p.Tools, t)
+			return t
+		}
+		if i == 0 {
+			// The first payload has all tools including ones without results.
+			for _, t := range wh.tools {
+				addTool(t.name, t.level)
+			}
+		}
+		for _, c := range cs {
+			// Remove OriginalOutput. It's used internally.
+			d := proto.Clone(c.Result.Diagnostic).(*rdf.Diagnostic)
+			d.OriginalOutput = ""
+			b, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(d)
+			if err != nil {
+				return nil, err
+			}
+			t := addTool(c.ToolName, wh.toolLevel(c.ToolName))
+			t.Results = append(t.Results, b)
+		}
+		payloads = append(payloads, p)
+	}
+	return payloads, nil
+}
+
+// severity returns lower case name of the severity.
+func severity(s rdf.Severity) string {
+	if s == rdf.Severity_UNKNOWN_SEVERITY {
+		return "unknown"
+	}
+	return strings.ToLower(s.String())
+}
+
+func (wh *Webhook) toolLevel(name string) string {
+	for _, t := range wh.tools {
+		if t.name == name {
+			return t.level
+		}
+	}
+	return ""
+}
+
+func (wh *Webhook) send(ctx context.Context, p *Payload) error {
+	body, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	wait := wh.opt.RetryWait
+	for attempt := 0; ; attempt++ {
+		retryable, err := wh.request(ctx, body)
+		if err == nil {
+			return nil
+		}
+		if !retryable || attempt >= wh.opt.MaxRetries {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+		wait *= 2
+	}
+}
+
+// request posts the body once. It returns true if the request can be retried
+// on error.
+func (wh *Webhook) request(ctx context.Context, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, wh.url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	for k, vs := range wh.opt.Header {
+		for _, v := range vs {
+			req.Header.Add(k, v)
+		}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "reviewdog/"+commands.Version)
+	if wh.opt.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign([]byte(wh.opt.Secret), body))
+	}
+	resp, err := wh.cli.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 == 2 {
+		return false, nil
+	}
+	b, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	return retryable, fmt.Errorf("unexpected status %s: %s", resp.Status, b)
+}
+
+// Sign returns HMAC-SHA256 signature of the body in "sha256=<hex digest>"
+// format.
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
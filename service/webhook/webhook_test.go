@@ -0,0 +1,138 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/reviewdog/reviewdog"
+	"github.com/reviewdog/reviewdog/cienv"
+	"github.com/reviewdog/reviewdog/filter"
+	"github.com/reviewdog/reviewdog/proto/rdf"
+)
+
+func comment(tool, path string, severity rdf.Severity) *reviewdog.Comment {
+	return &reviewdog.Comment{
+		Result: &filter.FilteredDiagnostic{Diagnostic: &rdf.Diagnostic{
+			Location:       &rdf.Location{Path: path},
+			Message:        "msg",
+			Severity:       severity,
+			OriginalOutput: "raw",
+		}},
+		ToolName: tool,
+	}
+}
+
+func TestWebhook_Flush(t *testing.T) {
+	var mu sync.Mutex
+	var payloads []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := r.Header.Get(SignatureHeader), Sign([]byte("secret"), body); got != want {
+			t.Errorf("signature = %q, want %q", got, want)
+		}
+		if got := r.Header.Get("X-Custom"); got != "value" {
+			t.Errorf("X-Custom header = %q, want value", got)
+		}
+		if got := r.Header.Get("Content-Type"); got != "application/json" {
+			t.Errorf("Content-Type = %q", got)
+		}
+		mu.Lock()
+		payloads = append(payloads, string(body))
+		mu.Unlock()
+	}))
+	defer ts.Close()
+
+	build := &cienv.BuildInfo{Owner: "o", Repo: "r", SHA: "sha", PullRequest: 14, Branch: "b"}
+	wh := NewWebhook(ts.Client(), ts.URL, build, &Option{
+		Secret:    "secret",
+		Header:    http.Header{"X-Custom": {"value"}},
+		BatchSize: 2,
+	})
+	wh.SetTool("golint", "warning")
+	wh.SetTool("clean", "error")
+	for _, c := range []*reviewdog.Comment{
+		comment("golint", "a.go", rdf.Severity_ERROR),
+		comment("golint", "b.go", rdf.Severity_WARNING),
+		comment("govet", "c.go", rdf.Severity_UNKNOWN_SEVERITY),
+	} {
+		if err := wh.Post(context.Background(), c); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := wh.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	summary := `"summary":{"total":3,"by_tool":{"clean":0,"golint":2,"govet":1},"by_severity":{"error":1,"unknown":1,"warning":1}}`
+	build1 := `"build":{"owner":"o","repo":"r","sha":"sha","pull_request":14,"branch":"b"}`
+	want := []string{
+		`{` + build1 + `,"batch":{"index":1,"total":2},` + summary + `,"tools":[` +
+			`{"name":"golint","level":"warning","results":[` +
+			`{"message":"msg","location":{"path":"a.go"},"severity":"ERROR"},` +
+			`{"message":"msg","location":{"path":"b.go"},"severity":"WARNING"}]},` +
+			`{"name":"clean","level":"error","results":[]}]}`,
+		`{` + build1 + `,"batch":{"index":2,"total":2},` + summary + `,"tools":[` +
+			`{"name":"govet","results":[{"message":"msg","location":{"path":"c.go"}}]}]}`,
+	}
+	// Normalize JSON to ignore spaces of protojson output.
+	for i := range payloads {
+		var v any
+		if err := json.Unmarshal([]byte(payloads[i]), &v); err != nil {
+			t.Fatal(err)
+		}
+		b, _ := json.Marshal(v)
+		payloads[i] = string(b)
+		if err := json.Unmarshal([]byte(want[i]), &v); err != nil {
+			t.Fatal(err)
+		}
+		b, _ = json.Marshal(v)
+		want[i] = string(b)
+	}
+	if diff := cmp.Diff(want, payloads); diff != "" {
+		t.Errorf("payloads diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestWebhook_retry(t *testing.T) {
+	tests := []struct {
+		name       string
+		statuses   []int
+		maxRetries int
+		wantReqs   int
+		wantErr    bool
+	}{
+		{name: "success after retries", statuses: []int{500, 429, 200}, maxRetries: 3, wantReqs: 3},
+		{name: "too many failures", statuses: []int{503, 503, 503}, maxRetries: 1, wantReqs: 2, wantErr: true},
+		{name: "no retry on client error", statuses: []int{400, 200}, maxRetries: 3, wantReqs: 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reqs := 0
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.statuses[reqs])
+				reqs++
+			}))
+			defer ts.Close()
+			wh := NewWebhook(ts.Client(), ts.URL, nil, &Option{MaxRetries: tt.maxRetries})
+			if err := wh.Post(context.Background(), comment("golint", "a.go", rdf.Severity_ERROR)); err != nil {
+				t.Fatal(err)
+			}
+			err := wh.Flush(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Flush() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if reqs != tt.wantReqs {
+				t.Errorf("got %d requests, want %d", reqs, tt.wantReqs)
+			}
+		})
+	}
+}